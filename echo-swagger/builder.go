@@ -49,20 +49,52 @@ func initLogger(logger *zerolog.Logger) {
 	log = zerolog.New(output).With().Timestamp().Logger()
 }
 
+// groupPrefix returns the longest prefix of group which contains the path.
+// Echo routes don't keep their group, so groups are found by routes with "/*"
+// at the end which echo adds for groups with middleware only. For path outside
+// such groups the empty prefix is returned and the tag is derived from the
+// first element of path relative to base path.
+func groupPrefix(routes []*echo.Route, path string) (prefix string) {
+	for _, r := range routes {
+		if !strings.HasSuffix(r.Path, "/*") {
+			continue
+		}
+		group := strings.TrimSuffix(r.Path, "/*")
+		if strings.HasPrefix(path, group+"/") && len(group) > len(prefix) {
+			prefix = group
+		}
+	}
+	return prefix
+}
+
+// endpoint is the descriptor of endpoint with its path and HTTP method
+type endpoint struct {
+	path       string
+	method     string
+	descriptor *swagger.Method
+}
+
 // BuildSwagger - build the OpenAPI Specification in JSON format
-func BuildSwagger(srv *echo.Echo, swaggerPath, address string, sw swagger.ISwaggerAPI, logger *zerolog.Logger) (err error) {
+func BuildSwagger(srv *echo.Echo, swaggerPath, address string, sw swagger.ISwaggerAPI, logger *zerolog.Logger,
+	opts ...func(o *swagger.BuildOptions)) (err error) {
 	initLogger(logger)
+	options := swagger.NewBuildOptions(opts...)
 
-	s := swagger.Doc{BaseAPI: *sw.(*swagger.BaseAPI)}
+	s := swagger.NewDoc(sw.(*swagger.BaseAPI))
+
+	if s.Host == "" {
+		s.Host = swagger.HostFromAddress(address)
+	}
 
 	log = log.With().Str("apiPath", address+s.BasePath).Logger()
 	log.Info().Msg("Build swagger")
 
 	ctx := srv.AcquireContext()
+	swagger.ClearDeprecations(srv)
 
-	s.Paths = make(map[string]swagger.Methods)
-	s.Definitions = make(map[string]*swagger.Definition)
-	for _, r := range srv.Routes() {
+	var endpoints []endpoint
+	routes := srv.Routes()
+	for _, r := range routes {
 		var method swagger.IMethod
 		ctx.Reset(&http.Request{URL: &url.URL{}}, &swagger.EmptyWriter{})
 		ctx.Set("swagger", &method)
@@ -102,15 +134,29 @@ func BuildSwagger(srv *echo.Echo, swaggerPath, address string, sw swagger.ISwagg
 		}
 
 		if m, ok := method.(*swagger.Method); ok {
-			m.Parse(path, r.Method, s)
+			if options.AutoTags {
+				m.SetAutoTag(swagger.TagFromPrefix(s.BasePath, groupPrefix(routes, r.Path), path))
+			}
 			m.OperationID = r.Name
+			swagger.RegisterDeprecation(srv, r.Method, r.Path, m)
+			s.RecordModels(m)
+			endpoints = append(endpoints, endpoint{path: path, method: r.Method, descriptor: m})
 		}
 	}
 
-	swagger.Register(address+s.BasePath, &s)
+	// The models of all endpoints are recorded before parsing, so the models
+	// used both in requests and in responses are known
+	for _, e := range endpoints {
+		e.descriptor.Parse(e.path, e.method, s)
+	}
+
+	swagger.Register(address+s.BasePath, s)
 
 	srv.GET(s.BasePath+swaggerPath, Handler(
 		swagger.Fill("doc.json", address+s.BasePath), // The url pointing to API definition"
+		func(c *swagger.Config) {
+			c.HostFromRequest = options.HostFromRequest
+		},
 	))
 
 	return nil
@@ -129,3 +175,14 @@ func ExcludeFromSwagger(next echo.HandlerFunc) echo.HandlerFunc {
 		return nil
 	}
 }
+
+// DeprecationHeaders - middleware which adds Deprecation and Sunset headers to
+// responses of endpoints described as deprecated.
+func DeprecationHeaders(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ec echo.Context) error {
+		if !IsBuildingSwagger(ec) {
+			swagger.SetDeprecationHeaders(ec.Response().Header(), ec.Echo(), ec.Request().Method, ec.Path())
+		}
+		return next(ec)
+	}
+}
@@ -34,7 +34,7 @@ func Handler(confs ...func(c *swagger.Config)) echo.HandlerFunc {
 	t := template.New("swagger_index.html")
 	index, _ := t.Parse(swagger.IndexTempl)
 
-	var re = regexp.MustCompile(`(.*)(index\.html|doc\.json|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[\?|.]*`)
+	var re = regexp.MustCompile(`(.*)(index\.html|doc\.json|doc\.yaml|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[\?|.]*`)
 
 	return func(c echo.Context) (err error) {
 		var matches []string
@@ -55,14 +55,10 @@ func Handler(confs ...func(c *swagger.Config)) echo.HandlerFunc {
 			if err != nil {
 				return
 			}
-		case "doc.json":
-			doc, err1 := swagger.ReadDoc(config.Name)
-			if err1 != nil {
-				return err1
-			}
-			_, err1 = c.Response().Write([]byte(doc))
-			if err1 != nil {
-				return err1
+		case "doc.json", "doc.yaml":
+			err = swagger.ServeDoc(c.Response(), c.Request(), path, config)
+			if err != nil {
+				return
 			}
 		case "":
 			err = c.Redirect(http.StatusMovedPermanently, prefix+"index.html")
@@ -127,7 +127,8 @@ func buildsrv2() {
 				SetDescription("This is a sample embedded Swagger-server for Echo-server.").
 				SetTermOfService("http://swagger.io/terms/").
 				SetContact(swagger.NewContact()).
-				SetLicense(swagger.NewLicense())),
+				SetLicense(swagger.NewLicense())).
+			SetSpecVersion(swagger.OpenAPI30),
 		nil,
 	)
 
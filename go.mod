@@ -8,4 +8,5 @@ require (
 	github.com/labstack/echo/v4 v4.1.17
 	github.com/rs/zerolog v1.20.0
 	github.com/swaggo/files v0.0.0-20190704085106-630677cd5c14
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -58,18 +58,39 @@ func initLogger(logger *zerolog.Logger) {
 	log = zerolog.New(output).With().Timestamp().Logger()
 }
 
+// subrouterPrefix returns the path prefix of the nearest subrouter
+func subrouterPrefix(ancestors []*mux.Route) string {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if prefix, err := ancestors[i].GetPathTemplate(); err == nil {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// endpoint is the descriptor of endpoint with its path and HTTP method
+type endpoint struct {
+	path       string
+	method     string
+	descriptor *swagger.Method
+}
+
 // BuildSwagger - build the OpenAPI Specification in JSON format
-func BuildSwagger(router *mux.Router, swaggerPath, address string, sw swagger.ISwaggerAPI, logger *zerolog.Logger) (err error) {
+func BuildSwagger(router *mux.Router, swaggerPath, address string, sw swagger.ISwaggerAPI, logger *zerolog.Logger,
+	opts ...func(o *swagger.BuildOptions)) (err error) {
 	initLogger(logger)
+	options := swagger.NewBuildOptions(opts...)
+
+	s := swagger.NewDoc(sw.(*swagger.BaseAPI))
 
-	s := swagger.Doc{BaseAPI: *sw.(*swagger.BaseAPI)}
+	if s.Host == "" {
+		s.Host = swagger.HostFromAddress(address)
+	}
 
 	log = log.With().Str("apiPath", address+s.BasePath).Logger()
 	log.Info().Msg("Build swagger")
 
-	s.Paths = make(map[string]swagger.Methods)
-	s.Definitions = make(map[string]*swagger.Definition)
-
+	var endpoints []endpoint
 	// Walk walks the router and all its sub-routers
 	err = router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
 		path, err1 := route.GetPathTemplate()
@@ -79,8 +100,10 @@ func BuildSwagger(router *mux.Router, swaggerPath, address string, sw swagger.IS
 		if !strings.HasPrefix(path, s.BasePath) {
 			return nil
 		}
+		routePath := path
 		path = strings.TrimPrefix(path, s.BasePath)
 		listMethods, _ := route.GetMethods()
+		swagger.ClearDeprecations(route)
 		for _, pathMethod := range listMethods {
 			var method swagger.IMethod
 			ctx := context.WithValue(context.Background(), SwaggerKey("swagger"), &method)
@@ -88,8 +111,13 @@ func BuildSwagger(router *mux.Router, swaggerPath, address string, sw swagger.IS
 			// Call endpoint handler
 			route.GetHandler().ServeHTTP(&swagger.EmptyWriter{}, req.WithContext(ctx))
 			if m, ok := method.(*swagger.Method); ok {
-				m.Parse(path, pathMethod, s)
+				if options.AutoTags {
+					m.SetAutoTag(swagger.TagFromPrefix(s.BasePath, subrouterPrefix(ancestors), path))
+				}
 				m.OperationID = handlerName(route.GetHandler())
+				swagger.RegisterDeprecation(route, pathMethod, routePath, m)
+				s.RecordModels(m)
+				endpoints = append(endpoints, endpoint{path: path, method: pathMethod, descriptor: m})
 			}
 		}
 
@@ -100,10 +128,19 @@ func BuildSwagger(router *mux.Router, swaggerPath, address string, sw swagger.IS
 		return
 	}
 
-	swagger.Register(address+s.BasePath, &s)
+	// The models of all endpoints are recorded before parsing, so the models
+	// used both in requests and in responses are known
+	for _, e := range endpoints {
+		e.descriptor.Parse(e.path, e.method, s)
+	}
+
+	swagger.Register(address+s.BasePath, s)
 
 	router.PathPrefix(s.BasePath + swaggerPath).Handler(Handler(
 		swagger.Fill("doc.json", address+s.BasePath), // The url pointing to API definition"
+		func(c *swagger.Config) {
+			c.HostFromRequest = options.HostFromRequest
+		},
 	))
 	return nil
 }
@@ -116,3 +153,18 @@ func ExcludeFromSwagger(next http.Handler) http.Handler {
 		}
 	})
 }
+
+// DeprecationHeaders - middleware which adds Deprecation and Sunset headers to
+// responses of endpoints described as deprecated.
+func DeprecationHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsBuildingSwagger(r) {
+			if route := mux.CurrentRoute(r); route != nil {
+				if path, err := route.GetPathTemplate(); err == nil {
+					swagger.SetDeprecationHeaders(w.Header(), route, r.Method, path)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -28,7 +28,7 @@ func Handler(confs ...func(c *swagger.Config)) http.HandlerFunc {
 	t := template.New("swagger_index.html")
 	index, _ := t.Parse(swagger.IndexTempl)
 
-	var re = regexp.MustCompile(`(.*)(index\.html|doc\.json|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[\?|.]*`)
+	var re = regexp.MustCompile(`(.*)(index\.html|doc\.json|doc\.yaml|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[\?|.]*`)
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		var matches []string
@@ -58,14 +58,10 @@ func Handler(confs ...func(c *swagger.Config)) http.HandlerFunc {
 			if err != nil {
 				log.Err(err).Msg("Error build template")
 			}
-		case "doc.json":
-			doc, err := swagger.ReadDoc(config.Name)
+		case "doc.json", "doc.yaml":
+			err := swagger.ServeDoc(w, r, path, config)
 			if err != nil {
-				log.Err(err).Msg("Error read doc")
-			}
-			_, err = w.Write([]byte(doc))
-			if err != nil {
-				log.Err(err).Msg("Error write bytes")
+				log.Err(err).Msg("Error serve doc")
 			}
 		case "":
 			http.Redirect(w, r, prefix+"index.html", http.StatusMovedPermanently)
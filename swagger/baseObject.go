@@ -8,26 +8,63 @@ type BaseObject struct {
 	TypeName string `json:"type,omitempty"`
 	// List of enumeration values
 	Enum []interface{} `json:"enum,omitempty"`
+	// Names of constants of enumeration values
+	EnumVarNames []string `json:"x-enum-varnames,omitempty"`
 	// Default value
 	Default interface{} `json:"default,omitempty"`
 	// Example of value
 	Example interface{} `json:"example,omitempty"`
-	// Could it be null?
-	Nullable string `json:"nullable,omitempty"`
+	// Could it be null? Swagger 2.0 has no such keyword, so the vendor
+	// extension is used and it is converted for OpenAPI 3.1
+	Nullable bool `json:"x-nullable,omitempty"`
+	// Is it free-form value encoded by custom MarshalJSON? The structure of
+	// such value is unknown, so the schema has no type
+	FreeForm bool `json:"x-free-form,omitempty"`
+	// Is it deprecated? Swagger 2.0 has no such keyword for schemas, so the
+	// vendor extension is used and it is converted for OpenAPI 3
+	Deprecated bool `json:"x-deprecated,omitempty"`
+	// Is it set by server only? Such property is ignored in requests
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// Is it sent by client only? Swagger 2.0 has no such keyword, so the
+	// vendor extension is used and it is converted for OpenAPI 3
+	WriteOnly bool `json:"x-write-only,omitempty"`
+	// Short title of object
+	Title string `json:"title,omitempty"`
 	// Detailed object description
 	Description string `json:"description,omitempty"`
 	// Name of object
 	Name string `json:"name,omitempty"`
 	// Object format, for example: int64 is integer with format int64
 	Format string `json:"format,omitempty"`
+	// Validation constraints of numbers
+	Minimum    *float64 `json:"minimum,omitempty"`
+	Maximum    *float64 `json:"maximum,omitempty"`
+	MultipleOf *float64 `json:"multipleOf,omitempty"`
+	// Validation constraints of strings
+	MinLength *int64 `json:"minLength,omitempty"`
+	MaxLength *int64 `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	// Validation constraints of arrays
+	MinItems    *int64 `json:"minItems,omitempty"`
+	MaxItems    *int64 `json:"maxItems,omitempty"`
+	UniqueItems bool   `json:"uniqueItems,omitempty"`
+	// Validation constraints of maps
+	MinProperties *int64 `json:"minProperties,omitempty"`
+	MaxProperties *int64 `json:"maxProperties,omitempty"`
 	// Reference to a schema definition
 	Ref string `json:"$ref,omitempty"`
 	// The object schema
 	Schema *Schema `json:"schema,omitempty"`
 	// Kind of object
 	Type reflect.Kind `json:"-"`
-	//
+	// Schema of values of map
 	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
+	// Schema of items of array
+	Item *BaseObject `json:"items,omitempty"`
+	// List of properties of inline object
+	Properties MapProperty `json:"properties,omitempty"`
+	// List of required properties of inline object
+	Required []string `json:"required,omitempty"`
 }
 
 func NewBaseObject(name, description string, t interface{}) *BaseObject {
@@ -0,0 +1,89 @@
+package swagger
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	benchAddress struct {
+		City    string `json:"city" validate:"required"`
+		Street  string `json:"street"`
+		ZipCode string `json:"zipCode" swagpattern:"^[0-9]{6}$"`
+	}
+	benchItem struct {
+		ID       int64             `json:"id" swagreadonly:"true"`
+		Title    string            `json:"title" swagdesc:"Title of item"`
+		Price    float64           `json:"price" validate:"min=0"`
+		Tags     []string          `json:"tags,omitempty"`
+		Attrs    map[string]string `json:"attrs,omitempty"`
+		Children []*benchItem      `json:"children,omitempty"`
+	}
+	benchOrder struct {
+		ID       int64        `json:"id" swagreadonly:"true"`
+		Created  time.Time    `json:"created"`
+		Customer benchUser    `json:"customer"`
+		Address  benchAddress `json:"address"`
+		Items    []benchItem  `json:"items"`
+		Comment  *string      `json:"comment"`
+	}
+	benchUser struct {
+		ID        int64          `json:"id" swagreadonly:"true"`
+		Name      string         `json:"name" validate:"required,min=1,max=64"`
+		Email     string         `json:"email" validate:"email"`
+		Password  string         `json:"password" swagwriteonly:"true"`
+		Addresses []benchAddress `json:"addresses"`
+	}
+)
+
+// Count of documents built by one iteration of benchmark
+const benchDocs = 10
+
+// resetCaches removes the fields and the definitions cached for types
+func resetCaches() {
+	for _, cache := range []*sync.Map{&fieldsCache, &definitionsCache} {
+		cache.Range(func(key, _ interface{}) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+}
+
+// buildBenchDocs builds the documents of every specification version, cold
+// build resets the caches before every document
+func buildBenchDocs(cold bool) {
+	for i := 0; i < benchDocs; i++ {
+		for _, version := range []string{Swagger20, OpenAPI30, OpenAPI31} {
+			if cold {
+				resetCaches()
+			}
+
+			api := NewSwagger().SetBasePath("/api/v1").SetInfo(NewInfo()).SetSpecVersion(version)
+			doc := NewDoc(api.(*BaseAPI))
+
+			m := NewMethod()
+			m.AddInBodyParameter("order", "Order", &benchOrder{}, true).
+				AddResponse(200, "Order", &benchOrder{})
+			m.Parse("/orders", "POST", doc)
+
+			m = NewMethod()
+			m.AddResponse(200, "Users", []benchUser{})
+			m.Parse("/users", "GET", doc)
+		}
+	}
+}
+
+func BenchmarkBuildCold(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		buildBenchDocs(true)
+	}
+}
+
+func BenchmarkBuildCached(b *testing.B) {
+	buildBenchDocs(false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buildBenchDocs(false)
+	}
+}
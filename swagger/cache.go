@@ -0,0 +1,151 @@
+package swagger
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// definitionKey is the key of cached definition, it contains the type and
+	// the settings of document which change the definition
+	definitionKey struct {
+		t             reflect.Type
+		version       string
+		split         bool
+		request       bool
+		embeddedAllOf bool
+		// Entry of naming function, the references of definition are checked
+		// on restoring, so the functions with the same entry are safe
+		namer uintptr
+		// Generation of global registries of types
+		generation uint64
+	}
+
+	// definitionDep is the definition referenced by cached definition, it is
+	// added to document with the cached definition
+	definitionDep struct {
+		t reflect.Type
+		// Is it the definition of interface?
+		iface bool
+		ref   string
+	}
+
+	// cachedDefinition is the definition built for type with the definitions
+	// referenced by it
+	cachedDefinition struct {
+		definition Definition
+		deps       []definitionDep
+	}
+
+	// definitionBuild collects the definitions referenced by definition which
+	// is being built
+	definitionBuild struct {
+		deps []definitionDep
+		// Is the definition built by dynamic types of values? Such definition
+		// is not cached
+		dynamic bool
+	}
+)
+
+var (
+	// Definitions built for types, they are shared by all documents
+	definitionsCache sync.Map
+	// Generation of global registries, the registration of type, interface or
+	// enumeration invalidates the cached definitions
+	registryGeneration uint64
+)
+
+// invalidateDefinitions invalidates the cached definitions after changing of
+// global registries
+func invalidateDefinitions() {
+	atomic.AddUint64(&registryGeneration, 1)
+}
+
+// definitionKey returns the key of cached definition of type, false means that
+// the definition is not cached because the document has own registries
+func (s *Doc) definitionKey(t reflect.Type) (definitionKey, bool) {
+	if s.builds == nil || len(s.Types) > 0 || len(s.Interfaces) > 0 || len(s.Enums) > 0 {
+		return definitionKey{}, false
+	}
+
+	key := definitionKey{
+		t:             t,
+		version:       s.Version,
+		split:         s.isSplit(t),
+		request:       s.request,
+		embeddedAllOf: s.EmbeddedAllOf,
+		generation:    atomic.LoadUint64(&registryGeneration),
+	}
+	if s.DefinitionNamer != nil {
+		key.namer = reflect.ValueOf(s.DefinitionNamer).Pointer()
+	}
+	return key, true
+}
+
+// buildDefinition builds the definition of structure and caches it
+func (s *Doc) buildDefinition(d *Definition, t reflect.Type, v reflect.Value) {
+	key, cached := s.definitionKey(t)
+	if cached && s.restoreDefinition(d, key) {
+		return
+	}
+
+	*d = Definition{}
+	build := &definitionBuild{}
+	*s.builds = append(*s.builds, build)
+	d.parse(t, v, s)
+	*s.builds = (*s.builds)[:len(*s.builds)-1]
+
+	// The implementation of interface is extended by the definition of
+	// interface while it is parsed, such definition depends on the order of
+	// parsing and is not cached
+	if cached && !build.dynamic && d.DiscriminatorValue == "" {
+		definitionsCache.Store(key, &cachedDefinition{definition: *d, deps: build.deps})
+	}
+}
+
+// restoreDefinition fills the definition from cache and adds the referenced
+// definitions to document, false means that the definition should be built
+// because it is not cached or its references differ in the document
+func (s *Doc) restoreDefinition(d *Definition, key definitionKey) bool {
+	cached, ok := definitionsCache.Load(key)
+	if !ok {
+		return false
+	}
+
+	c := cached.(*cachedDefinition)
+	*d = c.definition
+	for _, dep := range c.deps {
+		var ref string
+		if dep.iface {
+			ref = parseInterface(dep.t, s)
+		} else {
+			ref, _ = parseInterfaceOrStruct(dep.t, reflect.Value{}, s)
+		}
+		if ref != dep.ref {
+			return false
+		}
+	}
+	return true
+}
+
+// addDependency adds the referenced definition to the definition which is
+// being built
+func (s *Doc) addDependency(t reflect.Type, iface bool, ref string) {
+	if s.builds == nil || len(*s.builds) == 0 {
+		return
+	}
+	build := (*s.builds)[len(*s.builds)-1]
+	build.deps = append(build.deps, definitionDep{t: t, iface: iface, ref: ref})
+}
+
+// markDynamic marks the definitions which are being built as depending on the
+// dynamic types of values
+func (s *Doc) markDynamic() {
+	if s.builds == nil {
+		return
+	}
+	for _, build := range *s.builds {
+		build.dynamic = true
+	}
+}
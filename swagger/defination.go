@@ -1,134 +1,139 @@
 package swagger
 
 import (
+	"encoding/json"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
-type TypeDictElement struct {
-	TypeName string
-	Format   string
-}
-
-var (
-	// Dictinary with self-types
-	typeDict = map[string]TypeDictElement{
-		"Time": {
-			TypeName: "string",
-			Format:   "date-time",
-		},
-		"NullTime": {
-			TypeName: "string",
-			Format:   "date-time",
-		},
-		"UUID": {
-			TypeName: "string",
-			Format:   "uuid",
-		},
-		"NullString": {
-			TypeName: "string",
-			Format:   "",
-		},
-		"NullMeta": {
-			TypeName: "object",
-			Format:   "",
-		},
-	}
-)
-
 type Definition struct {
 	// Type name
 	TypeName string `json:"type,omitempty"`
+	// Short title of model
+	Title string `json:"title,omitempty"`
+	// Detailed model description
+	Description string `json:"description,omitempty"`
+	// Example of whole object
+	Example interface{} `json:"example,omitempty"`
+	// Is it deprecated? Swagger 2.0 has no such keyword for schemas, so the
+	// vendor extension is used and it is converted for OpenAPI 3
+	Deprecated bool `json:"x-deprecated,omitempty"`
+	// Additional external documentation of model
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+	// Discriminator of implementations of interface, it is the name of property
+	// in Swagger 2.0 and *Discriminator in OpenAPI 3
+	Discriminator interface{} `json:"discriminator,omitempty"`
+	// Value of discriminator for implementation of interface in Swagger 2.0
+	DiscriminatorValue string `json:"x-discriminator-value,omitempty"`
+	// Definitions extended by the definition
+	AllOf []*Property `json:"allOf,omitempty"`
+	// Implementations of interface in OpenAPI 3
+	OneOf []*Property `json:"oneOf,omitempty"`
 	// List of properties of definition object
 	Properties map[string]*Property `json:"properties,omitempty"`
+	// List of required properties
+	Required []string `json:"required,omitempty"`
 }
 
-// AddNewDefinition is a helper for add new definition in map
-func AddNewDefinition(objName string, s interface{}, sw *Doc) {
-	if _, ok := sw.Definitions[objName]; !ok {
-		sw.Definitions[objName] = &Definition{}
-		sw.Definitions[objName].Parse(s, sw)
-	}
+// DefinitionDescriber is implemented by structures which document their
+// definition, so the documentation of shared models lives next to the type.
+// The method is called on pointer to zero value.
+type DefinitionDescriber interface {
+	SwaggerDefinition() DefinitionInfo
 }
 
-func parseInterfaceOrStruct(obj interface{}, sw *Doc) (ref string) {
-	o := valueFromPtr(obj)
-	name := reflect.TypeOf(o).Name()
-	ref = "#/definitions/" + name
-	AddNewDefinition(name, o, sw)
-	return ref
+// DefinitionInfo is the documentation of model
+type DefinitionInfo struct {
+	Title       string
+	Description string
+	// Example of whole object, e.g. the filled structure
+	Example      interface{}
+	Deprecated   bool
+	ExternalDocs *ExternalDocs
 }
 
-func parseArrayOrSlice(obj interface{}, sw *Doc) *BaseObject {
-	var itemRef string
+var definitionDescriberType = reflect.TypeOf((*DefinitionDescriber)(nil)).Elem()
 
-	o := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
-
-	o = valueFromPtr(o)
-	if o == nil {
-		return buildBaseObject(reflect.Interface, itemRef)
+// AddNewDefinition is a helper for add new definition in map. The name is
+// registered for the type, so other types with the same name are named by the
+// package.
+func AddNewDefinition(objName string, s interface{}, sw *Doc) {
+	sw.init()
+	t, v := derefType(reflect.TypeOf(s), reflect.ValueOf(s))
+	if !sw.isNameTaken(objName) {
+		sw.registerName(objName, modelKey{t: t})
 	}
+	addDefinition(objName, t, v, sw)
+}
 
-	switch reflect.TypeOf(o).Kind() {
-	case reflect.Interface, reflect.Struct:
-		itemRef = parseInterfaceOrStruct(o, sw)
+func addDefinition(objName string, t reflect.Type, v reflect.Value, sw *Doc) {
+	if _, ok := sw.Definitions[objName]; !ok {
+		sw.Definitions[objName] = &Definition{}
+		sw.buildDefinition(sw.Definitions[objName], t, v)
 	}
-
-	return buildBaseObject(reflect.TypeOf(o).Kind(), itemRef)
 }
 
-func parseMap(obj interface{}, sw *Doc) *AdditionalProperties {
-	var addPropRef string
-
-	o := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
-
-	o = valueFromPtr(o)
-	if o == nil {
-		return buildAdditionalProperties(reflect.Interface, addPropRef)
+// parseInterfaceOrStruct returns the reference to definition of structure, the
+// anonymous structure has no name, so its definition is returned for inlining
+func parseInterfaceOrStruct(t reflect.Type, v reflect.Value, sw *Doc) (ref string, inline *Definition) {
+	t, v = derefType(t, v)
+	if t.Name() == "" {
+		inline = &Definition{}
+		inline.parse(t, v, sw)
+		return "", inline
 	}
 
-	switch reflect.TypeOf(o).Kind() {
-	case reflect.Interface, reflect.Struct:
-		addPropRef = parseInterfaceOrStruct(o, sw)
-	}
-	return buildAdditionalProperties(reflect.TypeOf(o).Kind(), addPropRef)
+	name := sw.modelName(t)
+	ref = sw.definitionRef(name)
+	sw.addDependency(t, false, ref)
+	addDefinition(name, t, v, sw)
+	return ref, nil
 }
 
-func buildBaseObject(kind reflect.Kind, ref string) *BaseObject {
-	tp, fmt := ParseKind(kind)
-	return &BaseObject{
-		TypeName: tp,
-		Ref:      ref,
-		Format:   fmt,
-	}
+// parseArrayOrSlice returns the schema of items of array, the items could be
+// nested containers, the pointer items are nullable
+func parseArrayOrSlice(t reflect.Type, sw *Doc) *BaseObject {
+	return &parseStructField(t.Elem(), reflect.Value{}, sw, "").BaseObject
 }
 
-func buildAdditionalProperties(kind reflect.Kind, ref string) *AdditionalProperties {
-	tp, fmt := ParseKind(kind)
-	return &AdditionalProperties{
-		Ref:      ref,
-		TypeName: tp,
-		Format:   fmt,
-	}
+// parseMap returns the schema of values of map, the values could be nested
+// containers, the pointer values are nullable
+func parseMap(t reflect.Type, sw *Doc) *AdditionalProperties {
+	return &parseStructField(t.Elem(), reflect.Value{}, sw, "").BaseObject
 }
 
-func valueFromPtr(s interface{}) interface{} {
-	if s == nil {
-		return nil
-	}
+// arrayLen returns the count of items of fixed-size array as its minimal and
+// maximal count
+func arrayLen(t reflect.Type) (minItems, maxItems *int64) {
+	l := int64(t.Len())
+	return &l, &l
+}
 
-	if reflect.TypeOf(s).Kind() == reflect.Ptr {
-		if !reflect.ValueOf(s).IsNil() {
-			return valueFromPtr(reflect.ValueOf(s).Elem().Interface())
+// derefType returns the type and the value pointed by pointers, the value is
+// invalid if it is not known or if the pointer is nil
+func derefType(t reflect.Type, v reflect.Value) (reflect.Type, reflect.Value) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+		} else {
+			v = reflect.Value{}
 		}
-		return valueFromPtr(reflect.New(reflect.TypeOf(s).Elem()).Interface())
 	}
-
-	return s
+	return t, v
 }
 
 // Parse definition of object
 func (d *Definition) Parse(s interface{}, sw *Doc) {
+	sw.init()
+	t, v := derefType(reflect.TypeOf(s), reflect.ValueOf(s))
+	d.parse(t, v, sw)
+}
+
+// parse builds the definition of structure by its type, the value is used only
+// for detecting the types of interface fields and could be invalid
+func (d *Definition) parse(t reflect.Type, v reflect.Value, sw *Doc) {
 	if d.TypeName == "" {
 		d.TypeName = constObject
 	}
@@ -137,84 +142,218 @@ func (d *Definition) Parse(s interface{}, sw *Doc) {
 		d.Properties = make(MapProperty)
 	}
 
-	Types := reflect.TypeOf(s)
-	Values := reflect.ValueOf(s)
+	// Embedded structures composed by allOf are described by definitions
+	composed := make(map[int]bool)
+	for _, i := range sw.composedFields(t) {
+		var val reflect.Value
+		if v.IsValid() {
+			val = v.Field(i)
+		}
+		ref, _ := parseInterfaceOrStruct(t.Field(i).Type, val, sw)
+		d.AllOf = append(d.AllOf, &Property{BaseObject: BaseObject{Ref: ref}})
+		composed[i] = true
+	}
+
+	// Walk through the fields of the structure encoded by encoding/json
+	split := sw.isSplit(t)
+	fields := cachedFields(t)
+	for i := range fields {
+		f := &fields[i]
+		if len(f.index) > 1 && composed[f.index[0]] || split && sw.skipField(&f.tags) {
+			continue
+		}
 
-	// Walk through all the fields of the structure
-	for j := 0; j < Types.NumField(); j++ {
-		var name string
+		var val reflect.Value
+		if v.IsValid() {
+			val = fieldValue(v, f.index)
+		}
 
-		tag, ok := Types.Field(j).Tag.Lookup("json")
-		if !ok {
-			name = Types.Field(j).Name
-		} else {
-			name = strings.Split(tag, ",")[0]
+		prop := parseStructField(f.field.Type, val, sw, f.swagType)
+		prop.applyTags(&f.tags)
+
+		// The definitions split for requests and responses need no markers
+		if split {
+			prop.ReadOnly, prop.WriteOnly = false, false
+		}
+
+		// Value is encoded as JSON string by string option
+		if hasTagOption(f.options, "string") {
+			prop.quote(f.field.Type)
 		}
 
-		// skip hided fields
-		if name == "-" {
+		d.Properties[f.name] = prop
+		// In Swagger 2.0 read-only properties should not be required
+		d.setRequired(f.name, f.required && !(prop.ReadOnly && !sw.IsOpenAPI3()))
+	}
+
+	d.describe(t)
+}
+
+// describe fills the documentation of definition provided by the structure
+func (d *Definition) describe(t reflect.Type) {
+	if !reflect.PtrTo(t).Implements(definitionDescriberType) {
+		return
+	}
+
+	info := reflect.New(t).Interface().(DefinitionDescriber).SwaggerDefinition()
+	d.Title = info.Title
+	d.Description = info.Description
+	d.Example = info.Example
+	d.Deprecated = info.Deprecated
+	d.ExternalDocs = info.ExternalDocs
+}
+
+// composedFields returns the indexes of structures embedded by value which are
+// composed by allOf instead of promoting their fields. The structures are
+// composed if it is enabled for document or by tag swagallof of field. The
+// anonymous structure is described by inline schema, so the fields embedded
+// in it are always promoted.
+func (s *Doc) composedFields(t reflect.Type) (indexes []int) {
+	if t.Name() == "" {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.Anonymous || sf.Type.Kind() != reflect.Struct {
 			continue
 		}
+		if name := strings.Split(sf.Tag.Get("json"), ",")[0]; name != "" {
+			continue
+		}
+
+		compose := s.EmbeddedAllOf
+		if allOf, ok := sf.Tag.Lookup("swagallof"); ok {
+			compose, _ = strconv.ParseBool(allOf)
+		}
+		if compose {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
 
-		// Parse swagtype for overriding
-		swagType, _ := Types.Field(j).Tag.Lookup("swagtype")
+// MarshalJSON moves the own properties of definition extending other
+// definitions to the last item of allOf
+func (d Definition) MarshalJSON() ([]byte, error) {
+	type definition Definition
+	if len(d.AllOf) == 0 || len(d.Properties) == 0 {
+		return json.Marshal(definition(d))
+	}
 
-		// Parse enumeration
-		var swagEnumArray []interface{}
-		if swagEnum, ok := Types.Field(j).Tag.Lookup("swagenum"); ok {
-			enumArray := strings.Split(swagEnum, ",")
-			if len(enumArray) > 0 {
-				swagEnumArray = make([]interface{}, 0, len(enumArray))
-				for _, item := range enumArray {
-					swagEnumArray = append(swagEnumArray, item)
-				}
+	allOf := make([]*Property, 0, len(d.AllOf)+1)
+	allOf = append(allOf, d.AllOf...)
+	allOf = append(allOf, &Property{
+		BaseObject: BaseObject{
+			TypeName:   d.TypeName,
+			Properties: d.Properties,
+			Required:   d.Required,
+		},
+	})
+
+	d.AllOf = allOf
+	d.TypeName = ""
+	d.Properties = nil
+	d.Required = nil
+	return json.Marshal(definition(d))
+}
+
+// setRequired adds the property to the list of required properties or removes
+// it
+func (d *Definition) setRequired(name string, required bool) {
+	for i, n := range d.Required {
+		if n == name {
+			if !required {
+				d.Required = append(d.Required[:i], d.Required[i+1:]...)
 			}
+			return
 		}
+	}
 
-		if Types.Field(j).Anonymous {
-			d.Parse(Values.Field(j).Interface(), sw)
-		} else {
-			d.Properties[name] = parseStructField(Types.Field(j).Type, Values.Field(j), sw, swagType, swagEnumArray)
+	if required {
+		d.Required = append(d.Required, name)
+	}
+}
+
+// quote describes the property of field with string option of json tag, the
+// option is applied to fields of string, floating point, integer or boolean
+// types and pointers to them only
+func (p *Property) quote(tp reflect.Type) {
+	if tp.Kind() == reflect.Ptr && tp.Name() == "" {
+		tp = tp.Elem()
+	}
+
+	switch tp.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if p.Ref == "" {
+			p.TypeName = "string"
 		}
 	}
 }
 
-func parseStructField(tp reflect.Type, val reflect.Value, sw *Doc, swagType string, swagEnum []interface{}) *Property {
+// hasTagOption reports whether the option is in the list of tag options
+func hasTagOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStructField returns the property of type, the value is used only for
+// detecting the dynamic types of interface fields and could be invalid
+func parseStructField(tp reflect.Type, val reflect.Value, sw *Doc, swagType string) *Property {
 	var (
 		addProp  *AdditionalProperties
 		item     *BaseObject
 		typeName string
 		ref      string
 		format   string
+		inline   *Definition
+		minItems *int64
+		maxItems *int64
 	)
 
-	obj := typeDict[tp.Name()]
-	if obj.TypeName != "" {
-		typeName = obj.TypeName
-		format = obj.Format
-	} else {
-		switch tp.Kind() {
-		case reflect.Interface:
-			typeName = constObject
-			if !val.IsNil() {
-				return parseStructField(reflect.TypeOf(val.Interface()), val, sw, swagType, swagEnum)
-			}
-		case reflect.Ptr:
-			return parseStructField(tp.Elem(), reflect.New(tp.Elem()), sw, swagType, swagEnum)
-		case reflect.Struct:
-			typeName = constObject
-			ref = parseInterfaceOrStruct(val.Interface(), sw)
-		case reflect.Array, reflect.Slice:
-			typeName = constArray
-			item = parseArrayOrSlice(val.Interface(), sw)
-		case reflect.Map:
-			typeName = constObject
-			addProp = parseMap(val.Interface(), sw)
-		default:
-			typeName, format = ParseKind(tp.Kind())
-			if swagType != "" {
-				typeName = swagType
-			}
+	if custom := sw.typeSchema(tp); custom != nil {
+		return custom.property()
+	}
+
+	switch tp.Kind() {
+	case reflect.Interface:
+		typeName = constObject
+		ref = parseInterface(tp, sw)
+		if ref == "" && val.IsValid() && !val.IsNil() {
+			sw.markDynamic()
+			return parseStructField(val.Elem().Type(), val.Elem(), sw, swagType)
+		}
+	case reflect.Ptr:
+		if val.IsValid() && !val.IsNil() {
+			val = val.Elem()
+		} else {
+			val = reflect.Value{}
+		}
+		prop := parseStructField(tp.Elem(), val, sw, swagType)
+		prop.Nullable = true
+		return prop
+	case reflect.Struct:
+		typeName = constObject
+		ref, inline = parseInterfaceOrStruct(tp, val, sw)
+	case reflect.Array, reflect.Slice:
+		typeName = constArray
+		item = parseArrayOrSlice(tp, sw)
+		if tp.Kind() == reflect.Array {
+			minItems, maxItems = arrayLen(tp)
+		}
+	case reflect.Map:
+		typeName = constObject
+		addProp = parseMap(tp, sw)
+	default:
+		typeName, format = ParseKind(tp.Kind())
+		if swagType != "" {
+			typeName = swagType
 		}
 	}
 
@@ -222,14 +361,22 @@ func parseStructField(tp reflect.Type, val reflect.Value, sw *Doc, swagType stri
 		typeName = ""
 	}
 
-	return &Property{
+	prop := &Property{
 		BaseObject: BaseObject{
 			TypeName:             typeName,
 			Ref:                  ref,
 			Format:               format,
-			Enum:                 swagEnum,
 			AdditionalProperties: addProp,
+			Item:                 item,
+			MinItems:             minItems,
+			MaxItems:             maxItems,
 		},
-		Item: item,
 	}
+
+	if inline != nil {
+		prop.Properties = inline.Properties
+		prop.Required = inline.Required
+	}
+
+	return prop
 }
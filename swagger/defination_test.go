@@ -0,0 +1,37 @@
+package swagger
+
+import (
+	"testing"
+)
+
+func TestAnonymousStructPromotesEmbedded(t *testing.T) {
+	type response struct {
+		Data struct {
+			Invoice `swagallof:"true"`
+			Paid    bool `json:"paid"`
+		} `json:"data"`
+	}
+
+	doc := NewDoc(&BaseAPI{Version: OpenAPI30, EmbeddedAllOf: true})
+	m := NewMethod()
+	m.AddResponse(200, "Invoice", &struct {
+		Invoice
+		Total float64 `json:"total"`
+	}{}).
+		AddResponse(201, "Response", &response{})
+	m.Parse("/invoices", "GET", doc)
+
+	schema := m.Responses["200"].Schema
+	for _, name := range []string{"number", "total"} {
+		if _, ok := schema.Properties[name]; !ok {
+			t.Errorf("inline schema has no property %s: %v", name, schema.Properties)
+		}
+	}
+
+	data := doc.Definitions["response"].Properties["data"]
+	for _, name := range []string{"number", "paid"} {
+		if _, ok := data.Properties[name]; !ok {
+			t.Errorf("inline property has no property %s: %v", name, data.Properties)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package swagger
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	deprecationMu sync.RWMutex
+	// Deprecated methods of routers by HTTP method and router path. The router
+	// is the key, so servers don't share the deprecated methods.
+	deprecations = make(map[interface{}]map[string]*Method)
+)
+
+// RegisterDeprecation registers the deprecated method of router for HTTP method
+// and router path, it is used by middlewares for adding Deprecation and Sunset
+// headers. The router is any comparable value identifying the routes, e.g. the
+// pointer to router or to route.
+func RegisterDeprecation(router interface{}, httpMethod, routePath string, m *Method) {
+	if m == nil || !m.Deprecated {
+		return
+	}
+
+	deprecationMu.Lock()
+	defer deprecationMu.Unlock()
+
+	if deprecations[router] == nil {
+		deprecations[router] = make(map[string]*Method)
+	}
+	deprecations[router][httpMethod+" "+routePath] = m
+}
+
+// ClearDeprecations removes the deprecated methods registered for router, it is
+// called before rebuilding of description and when the router is not used anymore
+func ClearDeprecations(router interface{}) {
+	deprecationMu.Lock()
+	defer deprecationMu.Unlock()
+
+	delete(deprecations, router)
+}
+
+// SetDeprecationHeaders sets Deprecation and Sunset headers if the endpoint of
+// router with HTTP method and router path is described as deprecated. The
+// endpoint has no date of deprecation, so the Deprecation header has value
+// "true" of draft-ietf-httpapi-deprecation-header instead of the date of RFC 9745.
+func SetDeprecationHeaders(h http.Header, router interface{}, httpMethod, routePath string) {
+	deprecationMu.RLock()
+	m, ok := deprecations[router][httpMethod+" "+routePath]
+	deprecationMu.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	h.Set("Deprecation", "true")
+	if m.Sunset != nil {
+		h.Set("Sunset", m.Sunset.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,167 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const constNull = "null"
+
+// Vendor extensions used by the builder for keywords missing in Swagger 2.0
+const (
+	xDeprecated = "x-deprecated"
+	xNullable   = "x-nullable"
+	xWriteOnly  = "x-write-only"
+)
+
+// convertSchemas converts schemas of the document from the Swagger 2.0 dialect
+// used by the builder to the dialect of OpenAPI 3.0 or to JSON Schema 2020-12
+// used by OpenAPI 3.1
+func (doc *openAPI3Doc) convertSchemas(jsonSchema2020 bool) {
+	convert := func(schema interface{}) interface{} {
+		return convertSchema(schema, jsonSchema2020)
+	}
+
+	if doc.Components != nil {
+		for name, schema := range doc.Components.Schemas {
+			doc.Components.Schemas[name] = convert(schema)
+		}
+	}
+
+	for _, methods := range doc.Paths {
+		for _, op := range methods {
+			for _, p := range op.Parameters {
+				p.Schema = convert(p.Schema)
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					media.Schema = convert(media.Schema)
+				}
+			}
+			for _, r := range op.Responses {
+				for _, media := range r.Content {
+					media.Schema = convert(media.Schema)
+				}
+				for _, h := range r.Headers {
+					h.Schema = convert(h.Schema)
+				}
+			}
+		}
+	}
+}
+
+// convertSchema converts the schema object to OpenAPI 3 dialect
+func convertSchema(schema interface{}, jsonSchema2020 bool) interface{} {
+	if schema == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+
+	var obj interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return schema
+	}
+
+	return convertSchemaObject(obj, jsonSchema2020)
+}
+
+// convertSchemaObject walks through the decoded schema and its subschemas
+func convertSchemaObject(obj interface{}, jsonSchema2020 bool) interface{} {
+	schema, ok := obj.(map[string]interface{})
+	if !ok {
+		return obj
+	}
+
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := schema[key]; ok {
+			schema[key] = convertSchemaObject(sub, jsonSchema2020)
+		}
+	}
+
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, sub := range props {
+			props[name] = convertSchemaObject(sub, jsonSchema2020)
+		}
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema[key].([]interface{}); ok {
+			for i, sub := range list {
+				list[i] = convertSchemaObject(sub, jsonSchema2020)
+			}
+		}
+	}
+
+	if deprecated, ok := schema[xDeprecated]; ok {
+		delete(schema, xDeprecated)
+		schema["deprecated"] = deprecated
+	}
+
+	if writeOnly, ok := schema[xWriteOnly]; ok {
+		delete(schema, xWriteOnly)
+		schema["writeOnly"] = writeOnly
+	}
+
+	if jsonSchema2020 {
+		convertJSONSchema2020(schema)
+	} else {
+		convertNullable(schema)
+	}
+
+	return schema
+}
+
+// convertNullable converts the nullable extension to the nullable keyword of
+// OpenAPI 3.0, the siblings of $ref are ignored, so the reference is wrapped by
+// allOf
+func convertNullable(schema map[string]interface{}) {
+	nullable, _ := schema[xNullable].(bool)
+	delete(schema, xNullable)
+	if !nullable {
+		return
+	}
+
+	schema["nullable"] = true
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		schema["enum"] = append(enum, nil)
+	}
+	if ref, ok := schema["$ref"]; ok {
+		schema["allOf"] = []interface{}{
+			map[string]interface{}{"$ref": ref},
+		}
+		delete(schema, "$ref")
+	}
+}
+
+// convertJSONSchema2020 converts keywords which differ in JSON Schema 2020-12
+func convertJSONSchema2020(schema map[string]interface{}) {
+	// examples is an array in JSON Schema
+	if example, ok := schema["example"]; ok {
+		delete(schema, "example")
+		schema["examples"] = []interface{}{example}
+	}
+
+	// nullable is expressed as a union with the null type
+	if nullable, _ := schema[xNullable].(bool); nullable {
+		delete(schema, xNullable)
+		switch {
+		case schema["type"] != nil:
+			schema["type"] = []interface{}{schema["type"], constNull}
+			if enum, ok := schema["enum"].([]interface{}); ok {
+				schema["enum"] = append(enum, nil)
+			}
+		case schema["$ref"] != nil:
+			schema["anyOf"] = []interface{}{
+				map[string]interface{}{"$ref": schema["$ref"]},
+				map[string]interface{}{"type": constNull},
+			}
+			delete(schema, "$ref")
+		}
+	}
+}
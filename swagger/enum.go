@@ -0,0 +1,56 @@
+package swagger
+
+import (
+	"reflect"
+)
+
+// EnumValue is the allowed value of enumeration with the name of its constant,
+// the name is emitted as x-enum-varnames for generators of clients
+type EnumValue struct {
+	Name  string
+	Value interface{}
+}
+
+// Dictionary with allowed values of types
+var enumDict = make(map[reflect.Type][]EnumValue)
+
+// RegisterEnum registers the allowed values of named type for all documents,
+// the values keep the order of registration, e.g.
+// RegisterEnum(reflect.TypeOf(Status("")), EnumValue{"StatusActive", StatusActive}, EnumValue{"StatusBlocked", StatusBlocked})
+func RegisterEnum(t reflect.Type, values ...EnumValue) {
+	typeDictMu.Lock()
+	defer typeDictMu.Unlock()
+
+	enumDict[t] = values
+	invalidateDefinitions()
+}
+
+// lookupEnum returns the allowed values of type registered for document or for
+// all documents, the document registry is preferred
+func (s *Doc) lookupEnum(t reflect.Type) ([]EnumValue, bool) {
+	if values, ok := s.Enums[t]; ok {
+		return values, true
+	}
+
+	typeDictMu.RLock()
+	defer typeDictMu.RUnlock()
+
+	values, ok := enumDict[t]
+	return values, ok
+}
+
+// enumSchema returns the schema of simple type with the allowed values
+func enumSchema(t reflect.Type, values []EnumValue) *Schema {
+	typeName, format := ParseKind(t.Kind())
+	schema := &Schema{
+		TypeName:     typeName,
+		Format:       format,
+		Enum:         make([]interface{}, 0, len(values)),
+		EnumVarNames: make([]string, 0, len(values)),
+	}
+	for _, v := range values {
+		schema.Enum = append(schema.Enum, v.Value)
+		schema.EnumVarNames = append(schema.EnumVarNames, v.Name)
+	}
+	return schema
+}
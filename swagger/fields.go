@@ -0,0 +1,386 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// fieldInfo is the description of field of structure built from its type and
+// tags, it does not depend on document, so it is shared by all documents
+type fieldInfo struct {
+	structField
+	// Type overriding the type of simple field
+	swagType string
+	// Is the field required?
+	required bool
+	// Annotations, enumeration, deprecation, read-only and write-only markers
+	// and validation constraints from tags
+	tags Property
+}
+
+// Tags with annotations of fields
+const (
+	tagDescription = "swagdesc"
+	tagTitle       = "title"
+	tagFormat      = "format"
+	tagExample     = "example"
+	tagDefault     = "default"
+)
+
+var (
+	// Descriptions of fields of structures by types
+	fieldsCache sync.Map
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// cachedFields returns the description of fields of structure, the fields are
+// parsed once for the type
+func cachedFields(t reflect.Type) []fieldInfo {
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.([]fieldInfo)
+	}
+
+	visible := visibleFields(t)
+	fields := make([]fieldInfo, 0, len(visible))
+	for _, sf := range visible {
+		f := fieldInfo{structField: sf}
+		f.swagType = sf.field.Tag.Get("swagtype")
+
+		// Parse enumeration, the values are converted to the kind of field
+		if swagEnum, ok := sf.field.Tag.Lookup("swagenum"); ok {
+			ft := sf.field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			for _, item := range strings.Split(swagEnum, ",") {
+				f.tags.Enum = append(f.tags.Enum, parseKindValue(ft.Kind(), strings.TrimSpace(item)))
+			}
+		}
+
+		// Parse deprecation
+		f.tags.Deprecated, _ = strconv.ParseBool(sf.field.Tag.Get("swagdeprecated"))
+
+		// Field is required if it is always present in JSON or if it is
+		// required by validation, swagrequired tag overrides it. The fields
+		// promoted through embedded pointer are absent in JSON when the
+		// pointer is nil, so they are present always only if it is required
+		// by validation or by swagrequired tag
+		f.required = sf.field.Type.Kind() != reflect.Ptr && !hasTagOption(sf.options, "omitempty") && !sf.viaPointer
+		if f.tags.parseConstraints(sf.field) {
+			f.required = true
+		}
+		if swagRequired, ok := sf.field.Tag.Lookup("swagrequired"); ok {
+			f.required, _ = strconv.ParseBool(swagRequired)
+		}
+
+		f.tags.parseAnnotations(sf.field)
+
+		// Parse read-only and write-only markers
+		f.tags.ReadOnly, _ = strconv.ParseBool(sf.field.Tag.Get("swagreadonly"))
+		f.tags.WriteOnly, _ = strconv.ParseBool(sf.field.Tag.Get("swagwriteonly"))
+
+		fields = append(fields, f)
+	}
+
+	cached, _ := fieldsCache.LoadOrStore(t, fields)
+	return cached.([]fieldInfo)
+}
+
+// parseAnnotations fills description, title, format, example and default value
+// of property from the tags, the example and default value are converted to
+// the type of field
+func (p *Property) parseAnnotations(field reflect.StructField) {
+	p.Description = field.Tag.Get(tagDescription)
+	p.Title = field.Tag.Get(tagTitle)
+	if format, ok := field.Tag.Lookup(tagFormat); ok {
+		p.Format = format
+	}
+	if example, ok := field.Tag.Lookup(tagExample); ok {
+		p.Example = parseTagValue(field.Type, example)
+	}
+	if def, ok := field.Tag.Lookup(tagDefault); ok {
+		p.Default = parseTagValue(field.Type, def)
+	}
+}
+
+// parseTagValue converts the value from tag to the type: numbers and booleans
+// are converted by kind, time is parsed in RFC 3339 layout, containers and
+// structures are parsed from JSON. The string is returned if conversion failed.
+func parseTagValue(t reflect.Type, s string) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		if tm, err := time.Parse(time.RFC3339, s); err == nil {
+			return tm
+		}
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v
+		}
+		return s
+	}
+
+	return parseKindValue(t.Kind(), s)
+}
+
+// applyTags copies annotations, enumeration, deprecation, read-only and
+// write-only markers and validation constraints parsed from tags of field to
+// the property
+func (p *Property) applyTags(tags *Property) {
+	if tags.Enum != nil {
+		p.Enum = tags.Enum
+		// The names of constants of registered enumeration do not match
+		p.EnumVarNames = nil
+	}
+	if tags.Description != "" {
+		p.Description = tags.Description
+	}
+	if tags.Title != "" {
+		p.Title = tags.Title
+	}
+	if tags.Example != nil {
+		p.Example = tags.Example
+	}
+	if tags.Default != nil {
+		p.Default = tags.Default
+	}
+	if tags.Format != "" {
+		p.Format = tags.Format
+	}
+	p.Deprecated = tags.Deprecated
+	p.ReadOnly = tags.ReadOnly
+	p.WriteOnly = tags.WriteOnly
+
+	if tags.Minimum != nil {
+		p.Minimum = tags.Minimum
+	}
+	if tags.Maximum != nil {
+		p.Maximum = tags.Maximum
+	}
+	if tags.MultipleOf != nil {
+		p.MultipleOf = tags.MultipleOf
+	}
+	if tags.MinLength != nil {
+		p.MinLength = tags.MinLength
+	}
+	if tags.MaxLength != nil {
+		p.MaxLength = tags.MaxLength
+	}
+	if tags.Pattern != "" {
+		p.Pattern = tags.Pattern
+	}
+	if tags.MinItems != nil {
+		p.MinItems = tags.MinItems
+	}
+	if tags.MaxItems != nil {
+		p.MaxItems = tags.MaxItems
+	}
+	if tags.UniqueItems {
+		p.UniqueItems = true
+	}
+	if tags.MinProperties != nil {
+		p.MinProperties = tags.MinProperties
+	}
+	if tags.MaxProperties != nil {
+		p.MaxProperties = tags.MaxProperties
+	}
+}
+
+// structField is a field of structure which is encoded by encoding/json
+type structField struct {
+	// Name of property
+	name string
+	// Is the name set by json tag?
+	tagged bool
+	// Options of json tag
+	options []string
+	// Index sequence of field, the fields of embedded structures are promoted
+	index []int
+	// Is the field promoted through pointer? Such field is omitted when the
+	// pointer is nil
+	viaPointer bool
+	field      reflect.StructField
+}
+
+// visibleFields returns the fields of structure by the rules of encoding/json:
+// unexported fields are skipped, the fields of embedded structures are
+// promoted and the conflicts of names are resolved by depth and by json tag
+func visibleFields(t reflect.Type) []structField {
+	type embedded struct {
+		typ        reflect.Type
+		index      []int
+		viaPointer bool
+	}
+
+	var (
+		fields       []structField
+		current      []embedded
+		next         = []embedded{{typ: t}}
+		count        map[reflect.Type]int
+		nextCount    = map[reflect.Type]int{}
+		visitedTypes = map[reflect.Type]bool{}
+	)
+
+	// Walk through the structure and its embedded structures level by level
+	for len(next) > 0 {
+		current, next = next, nil
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visitedTypes[e.typ] {
+				continue
+			}
+			visitedTypes[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					// Unexported embedded non-structures are ignored
+					if sf.PkgPath != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath != "" {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				options := strings.Split(tag, ",")
+				name := options[0]
+				if !isValidTag(name) {
+					name = ""
+				}
+
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				// Embedded structure without name in tag is promoted
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					nextCount[ft]++
+					if nextCount[ft] == 1 {
+						next = append(next, embedded{
+							typ:        ft,
+							index:      index,
+							viaPointer: e.viaPointer || sf.Type.Kind() == reflect.Ptr,
+						})
+					}
+					continue
+				}
+
+				f := structField{
+					name:       name,
+					tagged:     name != "",
+					options:    options[1:],
+					index:      index,
+					viaPointer: e.viaPointer,
+					field:      sf,
+				}
+				if f.name == "" {
+					f.name = sf.Name
+				}
+				fields = append(fields, f)
+
+				// The structure is embedded several times at the same level,
+				// so its fields annihilate each other
+				if count[e.typ] > 1 {
+					fields = append(fields, f)
+				}
+			}
+		}
+	}
+
+	// The field with the shortest index sequence dominates, the tagged field
+	// dominates at the same depth, otherwise all fields with the name are hidden
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+
+	visible := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].index) < len(fields[i+1].index) || fields[i].tagged != fields[i+1].tagged {
+			visible = append(visible, fields[i])
+		}
+		i = j
+	}
+
+	// Properties follow the order of fields
+	sort.Slice(visible, func(i, j int) bool {
+		x, y := visible[i].index, visible[j].index
+		for k := 0; k < len(x) && k < len(y); k++ {
+			if x[k] != y[k] {
+				return x[k] < y[k]
+			}
+		}
+		return len(x) < len(y)
+	})
+
+	return visible
+}
+
+// fieldValue returns the value of field by index sequence, the value is invalid
+// if the field is promoted through nil pointer
+func fieldValue(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// isValidTag reports whether the name in json tag is used by encoding/json
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but otherwise any
+			// punctuation chars are allowed in a tag name
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
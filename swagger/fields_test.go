@@ -0,0 +1,130 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	fieldsName struct {
+		Name string
+	}
+	fieldsTaggedName struct {
+		Name string `json:"Name"`
+	}
+	fieldsOtherName struct {
+		Name string
+		ID   int `json:"id"`
+	}
+	fieldsNested struct {
+		fieldsName
+	}
+	fieldsOtherNested struct {
+		fieldsName
+	}
+	fieldsUnexported struct {
+		Exported string
+		hidden   string
+	}
+	fieldsTaggedID struct {
+		ID int `json:"id"`
+	}
+	fieldsSlice []string
+)
+
+// jsonKeys returns the keys of object encoded by encoding/json in order
+func jsonKeys(t *testing.T, v interface{}) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	object, err := decodeOrdered(decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([]string, 0)
+	for _, item := range object.(yaml.MapSlice) {
+		keys = append(keys, item.Key.(string))
+	}
+	return keys
+}
+
+func TestVisibleFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"tagged dominates untagged at same depth", struct {
+			fieldsName
+			fieldsTaggedName
+		}{}},
+		{"untagged conflict at same depth", struct {
+			fieldsName
+			fieldsOtherName
+		}{}},
+		{"tagged conflict at same depth", struct {
+			fieldsTaggedName
+			fieldsTaggedID
+			ID int `json:"Name"`
+		}{}},
+		{"less nested dominates", struct {
+			fieldsNested
+			fieldsOtherName
+		}{}},
+		{"same structure embedded twice", struct {
+			fieldsNested
+			fieldsOtherNested
+		}{}},
+		{"embedded pointers", struct {
+			*fieldsName
+			*fieldsTaggedID
+			Title string `json:"title"`
+		}{&fieldsName{}, &fieldsTaggedID{}, ""}},
+		{"pointer conflicts with value", struct {
+			*fieldsName
+			fieldsOtherName
+		}{&fieldsName{}, fieldsOtherName{}}},
+		{"unexported embedded structures", struct {
+			fieldsUnexported
+			*fieldsTaggedID
+		}{fieldsUnexported{}, &fieldsTaggedID{}}},
+		{"unexported embedded pointer", struct {
+			*fieldsUnexported
+			Title string `json:"title"`
+		}{&fieldsUnexported{}, ""}},
+		{"skipped and dash named fields", struct {
+			Skipped string `json:"-"`
+			Dash    string `json:"-,"`
+			Renamed string `json:"renamed,omitempty"`
+		}{Renamed: "renamed"}},
+		{"embedded structure with name", struct {
+			fieldsName `json:"name"`
+			fieldsTaggedID
+		}{}},
+		{"embedded non-structure", struct {
+			fieldsSlice
+			Title string
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := make([]string, 0)
+			for _, f := range visibleFields(reflect.TypeOf(tt.value)) {
+				names = append(names, f.name)
+			}
+
+			if keys := jsonKeys(t, tt.value); !reflect.DeepEqual(names, keys) {
+				t.Errorf("properties %v, keys of json %v", names, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package swagger
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/soldatov-s/go-swagger/swagger/testdata/billing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type (
+	// Pet is described by polymorphic definition
+	Pet interface {
+		PetKind() string
+	}
+	Cat struct {
+		Name  string `json:"name" validate:"required"`
+		Lives int    `json:"lives" validate:"min=1,max=9"`
+	}
+	Dog struct {
+		Name    string `json:"name" validate:"required"`
+		Trained bool   `json:"trained"`
+	}
+
+	// User is used both in requests and in responses, it is split
+	User struct {
+		ID       int64   `json:"id" swagreadonly:"true"`
+		Name     string  `json:"name" validate:"required"`
+		Password string  `json:"password" swagwriteonly:"true"`
+		Pets     []Pet   `json:"pets,omitempty"`
+		Avatar   []byte  `json:"avatar,omitempty"`
+		Homepage *string `json:"homepage"`
+	}
+	// UserInput takes the name of definition describing User in requests
+	UserInput struct {
+		Invite string `json:"invite"`
+	}
+
+	// Invoice has the same name as billing.Invoice
+	Invoice struct {
+		Number string `json:"number" validate:"required"`
+	}
+	Order struct {
+		Invoice        Invoice         `json:"invoice"`
+		BillingInvoice billing.Invoice `json:"billingInvoice"`
+	}
+)
+
+func (Cat) PetKind() string { return "cat" }
+func (Dog) PetKind() string { return "dog" }
+
+// The interface is registered globally, so its definitions are cached
+func init() {
+	RegisterInterface(reflect.TypeOf((*Pet)(nil)).Elem(), "kind", map[string]interface{}{
+		"cat": Cat{},
+		"dog": Dog{},
+	})
+}
+
+// goldenDoc builds the document of the specification version describing the
+// same endpoints
+func goldenDoc(version string) *Doc {
+	api := NewSwagger().SetBasePath("/api/v1").
+		SetInfo(NewInfo().SetTitle("Golden API").SetVersion("1.0.0")).
+		SetSpecVersion(version).
+		SetHost("example.com").
+		SetSchemes("https").
+		AddSecurityDefinition("apiKey", NewAPIKeySecurity("X-API-Key", InHeader)).
+		SetSplitModels(true)
+	doc := NewDoc(api.(*BaseAPI))
+
+	endpoints := []struct {
+		path, method string
+		m            *Method
+	}{
+		{"/users", "POST", func() *Method {
+			m := NewMethod()
+			m.SetSummary("Create user").
+				AddInBodyParameter("user", "User", &User{}, true).
+				AddResponse(201, "Created user", &User{}).
+				AddSecurity("apiKey")
+			return m
+		}()},
+		{"/users/{id}", "GET", func() *Method {
+			m := NewMethod()
+			m.AddInPathParameter("id", "ID of user", reflect.Int64).
+				AddInQueryParameter("fields", "Fields of user", reflect.String, false).
+				AddResponse(200, "User", &User{}).
+				AddResponseHeader("X-Request-ID", "ID of request", reflect.String)
+			return m
+		}()},
+		{"/users/{id}", "DELETE", func() *Method {
+			m := NewMethod()
+			m.AddInPathParameter("id", "ID of user", reflect.Int64).
+				AddResponse(204, "Deleted", nil)
+			return m
+		}()},
+		{"/invites", "POST", func() *Method {
+			m := NewMethod()
+			m.AddInBodyParameter("invite", "Invite", &UserInput{}, true).
+				AddResponse(200, "Accepted invite", &UserInput{})
+			return m
+		}()},
+		{"/pets", "GET", func() *Method {
+			m := NewMethod()
+			m.AddResponse(200, "Pets", []Pet{})
+			m.SetDeprecated()
+			return m
+		}()},
+		{"/orders", "GET", func() *Method {
+			m := NewMethod()
+			m.AddResponse(200, "Order", &Order{})
+			return m
+		}()},
+	}
+
+	for _, e := range endpoints {
+		doc.RecordModels(e.m)
+	}
+	for _, e := range endpoints {
+		e.m.Parse(e.path, e.method, doc)
+	}
+
+	return doc
+}
+
+// checkGolden compares the data with the golden file, the file is rewritten if
+// the test is run with -update flag
+func checkGolden(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	golden, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, golden) {
+		t.Errorf("%s differs from golden file, run tests with -update to rewrite it:\n%s", name, data)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for _, version := range []string{Swagger20, OpenAPI30, OpenAPI31} {
+		t.Run(version, func(t *testing.T) {
+			doc := goldenDoc(version)
+			checkGolden(t, "golden-"+version+".json", append(doc.JSON(), '\n'))
+			checkGolden(t, "golden-"+version+".yaml", doc.YAML())
+		})
+	}
+}
+
+// TestGoldenCached checks that the document built from cached definitions
+// matches the document built from scratch
+func TestGoldenCached(t *testing.T) {
+	for _, version := range []string{Swagger20, OpenAPI30, OpenAPI31} {
+		resetCaches()
+		cold := goldenDoc(version).JSON()
+		cached := goldenDoc(version).JSON()
+		if !bytes.Equal(cold, cached) {
+			t.Errorf("%s: cached document differs:\n%s\n%s", version, cold, cached)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package swagger
 
 import (
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 type (
@@ -12,6 +14,7 @@ type (
 		Consumes    []string `json:"consumes,omitempty"`
 		Produces    []string `json:"produces,omitempty"`
 		Summary     string   `json:"summary,omitempty"`
+		Tags        []string `json:"tags,omitempty"`
 		OperationID string   `json:"operationId,omitempty"`
 		// The parameters of requests
 		Parameters ArrayParameters `json:"parameters,omitempty"`
@@ -19,6 +22,15 @@ type (
 		Responses MapResponse `json:"responses,omitempty"`
 		// Counters for same codes for multiple responses
 		ResponseCode MapResponseCode `json:"-"`
+		// Key of the last added response, headers are added to it
+		lastResponse string
+		// Is the endpoint deprecated?
+		Deprecated bool `json:"deprecated,omitempty"`
+		// Date after which the deprecated endpoint will be removed
+		Sunset *time.Time `json:"x-sunset,omitempty"`
+		// Security requirements, nil - the document security is used, empty -
+		// anonymous endpoint
+		Security *SecurityRequirements `json:"security,omitempty"`
 	}
 
 	// List of methods (GET, POST,...) for endpoint
@@ -35,6 +47,18 @@ type IMethod interface {
 	AddResponse(сode int, description string, schema interface{}) Responser
 	// AddFileParameter - adds a file response
 	AddFileResponse(responseCode int, description string) Responser
+	// AddSecurity - adds an alternative security requirement for the endpoint
+	AddSecurity(name string, scopes ...string) Responser
+	// AddSecurityRequirement - adds an alternative security requirement with several schemes for the endpoint
+	AddSecurityRequirement(requirement SecurityRequirement) Responser
+	// SetAnonymous - marks the endpoint as available without security
+	SetAnonymous() Responser
+	// AddTags - adds tags for grouping of endpoint
+	AddTags(tags ...string) Responser
+	// SetDeprecated - marks the endpoint as deprecated
+	SetDeprecated() Responser
+	// SetSunset - marks the endpoint as deprecated and sets the date of its removal
+	SetSunset(t time.Time) Responser
 }
 
 type Responser interface {
@@ -42,6 +66,22 @@ type Responser interface {
 	AddResponse(сode int, description string, schema interface{}) Responser
 	// AddFileParameter - adds a file response
 	AddFileResponse(responseCode int, description string) Responser
+	// AddResponseHeader - adds a header to the last added response
+	AddResponseHeader(name, description string, t reflect.Kind) Responser
+	// AddResponseHeaderFormat - adds a header with format to the last added response
+	AddResponseHeaderFormat(name, description string, t reflect.Kind, format string) Responser
+	// AddSecurity - adds an alternative security requirement for the endpoint
+	AddSecurity(name string, scopes ...string) Responser
+	// AddSecurityRequirement - adds an alternative security requirement with several schemes for the endpoint
+	AddSecurityRequirement(requirement SecurityRequirement) Responser
+	// SetAnonymous - marks the endpoint as available without security
+	SetAnonymous() Responser
+	// AddTags - adds tags for grouping of endpoint
+	AddTags(tags ...string) Responser
+	// SetDeprecated - marks the endpoint as deprecated
+	SetDeprecated() Responser
+	// SetSunset - marks the endpoint as deprecated and sets the date of its removal
+	SetSunset(t time.Time) Responser
 }
 
 type AdderInParameter interface {
@@ -53,6 +93,14 @@ type AdderInParameter interface {
 	AddInHeaderParameter(name, description string, t reflect.Kind, required bool) AdderInParameter
 	// AddInCookieParameter - adds a request in cookie parameter
 	AddInCookieParameter(name, description string, t reflect.Kind, required bool) AdderInParameter
+	// AddInPathParameterType - adds a request in path parameter of type, t is a value or reflect.Type
+	AddInPathParameterType(name, description string, t interface{}) AdderInParameter
+	// AddInQueryParameterType - adds a request in query parameter of type, t is a value or reflect.Type
+	AddInQueryParameterType(name, description string, t interface{}, required bool) AdderInParameter
+	// AddInHeaderParameterType - adds a request in header parameter of type, t is a value or reflect.Type
+	AddInHeaderParameterType(name, description string, t interface{}, required bool) AdderInParameter
+	// AddInCookieParameterType - adds a request in cookie parameter of type, t is a value or reflect.Type
+	AddInCookieParameterType(name, description string, t interface{}, required bool) AdderInParameter
 	// AddInFileParameter - adds a request in file parameter
 	AddInFileParameter(name, description string) AdderInParameter
 	Responser
@@ -63,6 +111,18 @@ type ParameterAndResponser interface {
 	AddResponse(Code int, description string, schema interface{}) Responser
 	// AddFileParameter - adds a file response
 	AddFileResponse(responseCode int, description string) Responser
+	// AddSecurity - adds an alternative security requirement for the endpoint
+	AddSecurity(name string, scopes ...string) Responser
+	// AddSecurityRequirement - adds an alternative security requirement with several schemes for the endpoint
+	AddSecurityRequirement(requirement SecurityRequirement) Responser
+	// SetAnonymous - marks the endpoint as available without security
+	SetAnonymous() Responser
+	// AddTags - adds tags for grouping of endpoint
+	AddTags(tags ...string) Responser
+	// SetDeprecated - marks the endpoint as deprecated
+	SetDeprecated() Responser
+	// SetSunset - marks the endpoint as deprecated and sets the date of its removal
+	SetSunset(t time.Time) Responser
 	// AddInBodyParameter - adds a request in body parameter
 	AddInBodyParameter(name, description string, t interface{}, required bool) AdderInParameter
 	// AddInPathParameter - adds a request in path parameter
@@ -73,6 +133,14 @@ type ParameterAndResponser interface {
 	AddInHeaderParameter(name, description string, t reflect.Kind, required bool) AdderInParameter
 	// AddInCookieParameter - adds a request in cookie parameter
 	AddInCookieParameter(name, description string, t reflect.Kind, required bool) AdderInParameter
+	// AddInPathParameterType - adds a request in path parameter of type, t is a value or reflect.Type
+	AddInPathParameterType(name, description string, t interface{}) AdderInParameter
+	// AddInQueryParameterType - adds a request in query parameter of type, t is a value or reflect.Type
+	AddInQueryParameterType(name, description string, t interface{}, required bool) AdderInParameter
+	// AddInHeaderParameterType - adds a request in header parameter of type, t is a value or reflect.Type
+	AddInHeaderParameterType(name, description string, t interface{}, required bool) AdderInParameter
+	// AddInCookieParameterType - adds a request in cookie parameter of type, t is a value or reflect.Type
+	AddInCookieParameterType(name, description string, t interface{}, required bool) AdderInParameter
 	// AddInFileParameter - adds a request in file parameter
 	AddInFileParameter(name, description string) AdderInParameter
 }
@@ -157,6 +225,31 @@ func (m *Method) AddInHeaderParameter(name, description string, t reflect.Kind,
 	return m.addIn(name, description, t, required, InHeader)
 }
 
+// parameterValue returns the zero value of type, the value of other types is
+// returned as is
+func parameterValue(t interface{}) interface{} {
+	if tp, ok := t.(reflect.Type); ok {
+		return reflect.Zero(tp).Interface()
+	}
+	return t
+}
+
+func (m *Method) AddInPathParameterType(name, description string, t interface{}) AdderInParameter {
+	return m.addIn(name, description, parameterValue(t), true, InPath)
+}
+
+func (m *Method) AddInQueryParameterType(name, description string, t interface{}, required bool) AdderInParameter {
+	return m.addIn(name, description, parameterValue(t), required, InQuery)
+}
+
+func (m *Method) AddInCookieParameterType(name, description string, t interface{}, required bool) AdderInParameter {
+	return m.addIn(name, description, parameterValue(t), required, InCookie)
+}
+
+func (m *Method) AddInHeaderParameterType(name, description string, t interface{}, required bool) AdderInParameter {
+	return m.addIn(name, description, parameterValue(t), required, InHeader)
+}
+
 func (m *Method) AddInBodyParameter(name, description string, t interface{}, required bool) AdderInParameter {
 	return m.addIn(name, description, t, required, InBody)
 }
@@ -182,7 +275,8 @@ func (m *Method) AddResponse(responseCode int, description string, schema interf
 		response.Schema = NewSchema(schema)
 	}
 
-	m.Responses[m.ResponseCode.Append(responseCode)] = response
+	m.lastResponse = m.ResponseCode.Append(responseCode)
+	m.Responses[m.lastResponse] = response
 
 	return m
 }
@@ -200,19 +294,105 @@ func (m *Method) AddFileResponse(responseCode int, description string) Responser
 		TypeName: "file",
 	}
 
-	m.Responses[m.ResponseCode.Append(responseCode)] = response
+	m.lastResponse = m.ResponseCode.Append(responseCode)
+	m.Responses[m.lastResponse] = response
+
+	return m
+}
+
+func (m *Method) AddResponseHeader(name, description string, t reflect.Kind) Responser {
+	return m.AddResponseHeaderFormat(name, description, t, "")
+}
+
+func (m *Method) AddResponseHeaderFormat(name, description string, t reflect.Kind, format string) Responser {
+	if m == nil {
+		return nil
+	}
+	if response, ok := m.Responses[m.lastResponse]; ok {
+		response.AddHeader(name, NewHeader(description, t, format))
+	}
+	return m
+}
+
+func (m *Method) AddSecurity(name string, scopes ...string) Responser {
+	if m == nil {
+		return nil
+	}
+	if m.Security == nil {
+		m.Security = &SecurityRequirements{}
+	}
+	m.Security.Append(name, scopes...)
+	return m
+}
+
+func (m *Method) AddSecurityRequirement(requirement SecurityRequirement) Responser {
+	if m == nil {
+		return nil
+	}
+	if m.Security == nil {
+		m.Security = &SecurityRequirements{}
+	}
+	*m.Security = append(*m.Security, requirement)
+	return m
+}
+
+func (m *Method) SetAnonymous() Responser {
+	if m == nil {
+		return nil
+	}
+	m.Security = &SecurityRequirements{}
+	return m
+}
 
+func (m *Method) AddTags(tags ...string) Responser {
+	if m == nil {
+		return nil
+	}
+	m.Tags = append(m.Tags, tags...)
 	return m
 }
 
-func (m *Method) Parse(path, methodName string, sw Doc) {
+func (m *Method) SetDeprecated() Responser {
+	if m == nil {
+		return nil
+	}
+	m.Deprecated = true
+	return m
+}
+
+func (m *Method) SetSunset(t time.Time) Responser {
+	if m == nil {
+		return nil
+	}
+	m.Deprecated = true
+	m.Sunset = &t
+	return m
+}
+
+// SetAutoTag sets the tag of method if no tags were set by endpoint descriptor
+func (m *Method) SetAutoTag(tag string) {
+	if m == nil || len(m.Tags) > 0 || tag == "" {
+		return
+	}
+	m.Tags = []string{tag}
+}
+
+// Parse describes the endpoint in the document
+func (m *Method) Parse(path, methodName string, sw *Doc) {
+	sw.init()
+	sw.RecordModels(m)
 	// Parse parameters
 	for _, p := range m.Parameters {
 		p.Parse(sw)
 	}
-	// Parse responses
-	for _, r := range m.Responses {
-		r.Parse(sw)
+	// Parse responses in order of codes, so names of definitions are stable
+	codes := make([]string, 0, len(m.Responses))
+	for code := range m.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		m.Responses[code].Parse(sw)
 	}
 	if sw.Paths[path] == nil {
 		sw.Paths[path] = make(Methods)
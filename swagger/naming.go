@@ -0,0 +1,138 @@
+package swagger
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefinitionNamer returns the name of definition for the type
+type DefinitionNamer func(t reflect.Type) string
+
+var (
+	pkgPathReplacer = strings.NewReplacer("/", "_", ".", "_", "-", "_")
+	// Names of types in the name of instantiated generic type
+	typeNameToken = regexp.MustCompile(`[\w.\-/]+`)
+)
+
+// modelKey identifies the definition of type, the type with read-only or
+// write-only properties could be described by separate definition for requests
+type modelKey struct {
+	t       reflect.Type
+	request bool
+}
+
+// definitionName returns the name of definition for the type. The name of type
+// is used at first, if it is taken by another type, the name is qualified by
+// the package name and then by the package path. The custom naming function of
+// document replaces the name of type.
+func (s *Doc) definitionName(t reflect.Type) string {
+	key := modelKey{t: t}
+	if name, ok := s.definitionNames[key]; ok {
+		return name
+	}
+	return s.uniqueName(key, s.nameCandidates(t))
+}
+
+// requestDefinitionName returns the name of definition describing the type in
+// requests, it is the name of definition for responses with suffix. The names
+// of real types win, so the taken name is followed by a number, e.g. UserInput2
+// if the type UserInput is used by endpoints.
+func (s *Doc) requestDefinitionName(t reflect.Type) string {
+	key := modelKey{t: t, request: true}
+	if name, ok := s.definitionNames[key]; ok {
+		return name
+	}
+
+	base := s.definitionName(t) + requestModelSuffix
+	name := base
+	for i := 2; s.isNameTaken(name) || s.isModelName(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	s.registerName(name, key)
+	return name
+}
+
+// isModelName reports whether the name is preferred by the type recorded by
+// RecordModels, such type could be parsed later
+func (s *Doc) isModelName(name string) bool {
+	for _, types := range []map[reflect.Type]bool{s.requestTypes, s.responseTypes} {
+		for t := range types {
+			if t.Name() != "" && s.nameCandidates(t)[0] == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nameCandidates returns the names of definition for the type in order of
+// preference
+func (s *Doc) nameCandidates(t reflect.Type) []string {
+	if s.DefinitionNamer != nil {
+		return []string{s.DefinitionNamer(t)}
+	}
+
+	return []string{
+		typeName(t.Name(), func(string) string { return "" }),
+		path.Base(t.PkgPath()) + "." + typeName(t.Name(), func(pkgPath string) string {
+			return path.Base(pkgPath) + "."
+		}),
+		pkgPathReplacer.Replace(t.PkgPath()) + "." + typeName(t.Name(), func(pkgPath string) string {
+			return pkgPathReplacer.Replace(pkgPath) + "."
+		}),
+	}
+}
+
+// uniqueName registers the first free candidate as the name of definition
+func (s *Doc) uniqueName(key modelKey, candidates []string) string {
+	name := candidates[len(candidates)-1]
+	for _, candidate := range candidates {
+		if !s.isNameTaken(candidate) {
+			name = candidate
+			break
+		}
+	}
+
+	// The last resort is a numeric suffix
+	for i := 2; s.isNameTaken(name); i++ {
+		name = candidates[len(candidates)-1] + strconv.Itoa(i)
+	}
+
+	s.registerName(name, key)
+	return name
+}
+
+// registerName registers the name of definition describing the type, the type
+// keeps the name registered first
+func (s *Doc) registerName(name string, key modelKey) {
+	s.definitionTypes[name] = key
+	if _, ok := s.definitionNames[key]; !ok {
+		s.definitionNames[key] = name
+	}
+}
+
+// isNameTaken reports whether the name of definition is registered
+func (s *Doc) isNameTaken(name string) bool {
+	_, ok := s.definitionTypes[name]
+	return ok
+}
+
+// typeName returns the name of type which is safe for using in reference. The
+// type arguments of instantiated generic type are joined to the name with
+// underscore, e.g. Page[main.User] is named Page_User. The package paths of type
+// arguments are replaced by the result of qualify.
+func typeName(name string, qualify func(pkgPath string) string) string {
+	tokens := typeNameToken.FindAllString(name, -1)
+	for i, token := range tokens {
+		dot := strings.LastIndex(token, ".")
+		if i == 0 || dot < strings.LastIndex(token, "/") || dot < 0 {
+			continue
+		}
+		tokens[i] = qualify(token[:dot]) + token[dot+1:]
+	}
+	return strings.Join(tokens, "_")
+}
@@ -0,0 +1,80 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/soldatov-s/go-swagger/swagger/testdata/billing"
+)
+
+func TestDocLiteral(t *testing.T) {
+	doc := &Doc{
+		BaseAPI:     BaseAPI{Version: Swagger20},
+		Paths:       make(map[string]Methods),
+		Definitions: make(map[string]*Definition),
+	}
+
+	m := NewMethod()
+	m.AddInBodyParameter("user", "User", &User{}, true).
+		AddResponse(200, "User", &User{})
+	m.Parse("/users", "POST", doc)
+
+	if _, ok := doc.Definitions["User"]; !ok {
+		t.Errorf("definition User is not added: %v", doc.Definitions)
+	}
+	if doc.Paths["/users"]["post"] != m {
+		t.Error("method is not added to paths")
+	}
+
+	var d Definition
+	d.Parse(&Order{}, &Doc{})
+	if _, ok := d.Properties["invoice"]; !ok {
+		t.Errorf("property invoice is not parsed: %v", d.Properties)
+	}
+}
+
+func TestAddNewDefinitionName(t *testing.T) {
+	doc := NewDoc(NewSwagger().(*BaseAPI))
+	AddNewDefinition("Invoice", billing.Invoice{}, doc)
+
+	m := NewMethod()
+	m.AddResponse(200, "Invoice", &Invoice{})
+	m.Parse("/invoices", "GET", doc)
+
+	if ref := m.Responses["200"].Schema.Ref; ref != "#/definitions/swagger.Invoice" {
+		t.Errorf("reference of swagger.Invoice is %q", ref)
+	}
+	if _, ok := doc.Definitions["Invoice"].Properties["amount"]; !ok {
+		t.Error("definition Invoice doesn't describe billing.Invoice")
+	}
+}
+
+func TestRequestDefinitionName(t *testing.T) {
+	for _, invitesFirst := range []bool{false, true} {
+		api := &BaseAPI{Version: Swagger20, SplitModels: true}
+		doc := NewDoc(api)
+
+		users := NewMethod()
+		users.AddInBodyParameter("user", "User", &User{}, true).
+			AddResponse(200, "User", &User{})
+		invites := NewMethod()
+		invites.AddInBodyParameter("invite", "Invite", &UserInput{}, true)
+
+		methods := []*Method{users, invites}
+		if invitesFirst {
+			methods = []*Method{invites, users}
+		}
+		for _, m := range methods {
+			doc.RecordModels(m)
+		}
+		for _, m := range methods {
+			m.Parse("/", "POST", doc)
+		}
+
+		if ref := users.Parameters[0].Schema.Ref; ref != "#/definitions/UserInput2" {
+			t.Errorf("invites first %v: reference of request model of User is %q", invitesFirst, ref)
+		}
+		if ref := invites.Parameters[0].Schema.Ref; ref != "#/definitions/UserInput" {
+			t.Errorf("invites first %v: reference of UserInput is %q", invitesFirst, ref)
+		}
+	}
+}
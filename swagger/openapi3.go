@@ -0,0 +1,328 @@
+package swagger
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	mimeJSON        = "application/json"
+	mimeMultipart   = "multipart/form-data"
+	mimeURLEncoded  = "application/x-www-form-urlencoded"
+	mimeOctetStream = "application/octet-stream"
+	typeFile        = "file"
+)
+
+type (
+	// High level object for describing the builded API in OpenAPI 3 layout
+	openAPI3Doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    IInfo  `json:"info,omitempty"`
+		// List of servers, the base path is passed as relative server URL
+		Servers []*openAPI3Server `json:"servers,omitempty"`
+		// List of paths to endpoints
+		Paths map[string]map[string]*openAPI3Operation `json:"paths"`
+		// Reusable objects, replaces definitions of Swagger 2.0
+		Components *openAPI3Components  `json:"components,omitempty"`
+		Security   SecurityRequirements `json:"security,omitempty"`
+		Tags       []ITag               `json:"tags,omitempty"`
+	}
+	openAPI3Server struct {
+		URL string `json:"url"`
+	}
+	openAPI3Components struct {
+		Schemas         map[string]interface{}             `json:"schemas,omitempty"`
+		SecuritySchemes map[string]*openAPI3SecurityScheme `json:"securitySchemes,omitempty"`
+	}
+	openAPI3SecurityScheme struct {
+		Type        string                        `json:"type"`
+		Description string                        `json:"description,omitempty"`
+		Name        string                        `json:"name,omitempty"`
+		IN          InType                        `json:"in,omitempty"`
+		Scheme      string                        `json:"scheme,omitempty"`
+		Flows       map[string]*openAPI3OAuthFlow `json:"flows,omitempty"`
+	}
+	openAPI3OAuthFlow struct {
+		AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+		TokenURL         string            `json:"tokenUrl,omitempty"`
+		Scopes           map[string]string `json:"scopes"`
+	}
+	// Description the REST-method of endpoint in OpenAPI 3 layout
+	openAPI3Operation struct {
+		Tags        []string                     `json:"tags,omitempty"`
+		Summary     string                       `json:"summary,omitempty"`
+		Description string                       `json:"description,omitempty"`
+		OperationID string                       `json:"operationId,omitempty"`
+		Parameters  []*openAPI3Parameter         `json:"parameters,omitempty"`
+		RequestBody *openAPI3RequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPI3Response `json:"responses"`
+		Security    *SecurityRequirements        `json:"security,omitempty"`
+		Deprecated  bool                         `json:"deprecated,omitempty"`
+		Sunset      *time.Time                   `json:"x-sunset,omitempty"`
+	}
+	openAPI3Parameter struct {
+		Name        string      `json:"name"`
+		IN          InType      `json:"in"`
+		Description string      `json:"description,omitempty"`
+		Req         bool        `json:"required,omitempty"`
+		Schema      interface{} `json:"schema,omitempty"`
+	}
+	openAPI3RequestBody struct {
+		Description string                        `json:"description,omitempty"`
+		Req         bool                          `json:"required,omitempty"`
+		Content     map[string]*openAPI3MediaType `json:"content"`
+	}
+	openAPI3MediaType struct {
+		Schema interface{} `json:"schema,omitempty"`
+	}
+	openAPI3Response struct {
+		Description string                        `json:"description"`
+		Headers     map[string]*openAPI3Header    `json:"headers,omitempty"`
+		Content     map[string]*openAPI3MediaType `json:"content,omitempty"`
+	}
+	openAPI3Header struct {
+		Description string      `json:"description,omitempty"`
+		Schema      interface{} `json:"schema"`
+	}
+)
+
+// openAPI3 converts the document to OpenAPI 3 layout
+func (s *Doc) openAPI3() *openAPI3Doc {
+	doc := &openAPI3Doc{
+		OpenAPI:  s.Version,
+		Info:     s.Info,
+		Paths:    make(map[string]map[string]*openAPI3Operation),
+		Security: s.Security,
+		Tags:     s.Tags,
+	}
+
+	doc.Servers = s.openAPI3Servers()
+
+	for path, methods := range s.Paths {
+		doc.Paths[path] = make(map[string]*openAPI3Operation)
+		for name, method := range methods {
+			if m, ok := method.(*Method); ok {
+				doc.Paths[path][name] = m.openAPI3()
+			}
+		}
+	}
+
+	if len(s.Definitions) > 0 || len(s.SecurityDefinitions) > 0 {
+		doc.Components = &openAPI3Components{
+			Schemas:         make(map[string]interface{}),
+			SecuritySchemes: make(map[string]*openAPI3SecurityScheme),
+		}
+		for name, d := range s.Definitions {
+			doc.Components.Schemas[name] = d
+		}
+		for name, scheme := range s.SecurityDefinitions {
+			if sc, ok := scheme.(*SecurityScheme); ok {
+				doc.Components.SecuritySchemes[name] = sc.openAPI3()
+			}
+		}
+	}
+
+	doc.convertSchemas(s.IsOpenAPI31())
+
+	return doc
+}
+
+// openAPI3Servers returns the list of servers built from host, schemes and base
+// path, without host the base path is passed as relative server URL
+func (s *Doc) openAPI3Servers() (servers []*openAPI3Server) {
+	switch {
+	case s.Host == "" && s.BasePath == "":
+		return nil
+	case s.Host == "":
+		return []*openAPI3Server{{URL: s.BasePath}}
+	case len(s.Schemes) == 0:
+		return []*openAPI3Server{{URL: "//" + s.Host + s.BasePath}}
+	}
+
+	for _, scheme := range s.Schemes {
+		servers = append(servers, &openAPI3Server{URL: scheme + "://" + s.Host + s.BasePath})
+	}
+	return servers
+}
+
+// openAPI3 converts the method to OpenAPI 3 operation, the body and the file
+// parameters are moved to request body
+func (m *Method) openAPI3() *openAPI3Operation {
+	op := &openAPI3Operation{
+		Tags:        m.Tags,
+		Summary:     m.Summary,
+		Description: m.Description,
+		OperationID: m.OperationID,
+		Responses:   make(map[string]*openAPI3Response),
+		Security:    m.Security,
+		Deprecated:  m.Deprecated,
+		Sunset:      m.Sunset,
+	}
+
+	var form *Schema
+	for _, p := range m.Parameters {
+		switch p.IN {
+		case InBody:
+			op.RequestBody = &openAPI3RequestBody{
+				Description: p.Description,
+				Req:         p.Req,
+				Content:     mediaTypes(m.Consumes, mimeJSON, p.openAPI3Schema()),
+			}
+		case InFile:
+			if form == nil {
+				form = &Schema{
+					TypeName:   constObject,
+					Properties: make(MapProperty),
+				}
+			}
+			form.Properties[p.Name] = &Property{
+				BaseObject: BaseObject{
+					TypeName:    "string",
+					Format:      "binary",
+					Description: p.Description,
+				},
+			}
+			if p.Req {
+				form.Required = append(form.Required, p.Name)
+			}
+		default:
+			op.Parameters = append(op.Parameters, &openAPI3Parameter{
+				Name:        p.Name,
+				IN:          p.IN,
+				Description: p.Description,
+				Req:         p.Req,
+			})
+			if schema := p.openAPI3Schema(); schema != nil {
+				op.Parameters[len(op.Parameters)-1].Schema = schema
+			}
+		}
+	}
+
+	if form != nil {
+		var consumes []string
+		for _, c := range m.Consumes {
+			if c == mimeMultipart || c == mimeURLEncoded {
+				consumes = append(consumes, c)
+			}
+		}
+		op.RequestBody = &openAPI3RequestBody{
+			Req:     len(form.Required) > 0,
+			Content: mediaTypes(consumes, mimeMultipart, form),
+		}
+	}
+
+	for code, r := range m.Responses {
+		resp := &openAPI3Response{
+			Description: r.Description,
+		}
+		for name, h := range r.Headers {
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]*openAPI3Header)
+			}
+			resp.Headers[name] = &openAPI3Header{
+				Description: h.Description,
+				Schema: &Schema{
+					TypeName: h.TypeName,
+					Format:   h.Format,
+				},
+			}
+		}
+		if schema := r.openAPI3Schema(); schema != nil {
+			defaultMime := mimeJSON
+			if schema.Format == "binary" {
+				defaultMime = mimeOctetStream
+			}
+			resp.Content = mediaTypes(m.Produces, defaultMime, schema)
+		}
+		op.Responses[code] = resp
+	}
+
+	return op
+}
+
+// openAPI3 converts the security scheme to OpenAPI 3 layout, basic
+// authentication is described as http scheme and OAuth2 flows are renamed
+func (s *SecurityScheme) openAPI3() *openAPI3SecurityScheme {
+	scheme := &openAPI3SecurityScheme{
+		Type:        s.Type,
+		Description: s.Description,
+		Name:        s.Name,
+		IN:          s.IN,
+	}
+
+	switch s.Type {
+	case SecurityBasic:
+		scheme.Type = "http"
+		scheme.Scheme = SecurityBasic
+	case SecurityOAuth2:
+		flow := s.Flow
+		switch flow {
+		case OAuth2Application:
+			flow = "clientCredentials"
+		case OAuth2AccessCode:
+			flow = "authorizationCode"
+		}
+		scopes := s.Scopes
+		if scopes == nil {
+			scopes = make(map[string]string)
+		}
+		scheme.Flows = map[string]*openAPI3OAuthFlow{
+			flow: {
+				AuthorizationURL: s.AuthorizationURL,
+				TokenURL:         s.TokenURL,
+				Scopes:           scopes,
+			},
+		}
+	}
+
+	return scheme
+}
+
+// openAPI3Schema returns the schema of object, in OpenAPI 3 simple types are
+// described by schema too
+func (b *BaseObject) openAPI3Schema() *Schema {
+	if b.TypeName == typeFile || (b.Schema != nil && b.Schema.TypeName == typeFile) {
+		return &Schema{
+			TypeName: "string",
+			Format:   "binary",
+		}
+	}
+
+	if b.Schema != nil && (b.Schema.TypeName != "" || b.Schema.Ref != "" || b.Schema.FreeForm) {
+		return b.Schema
+	}
+
+	if b.TypeName == "" {
+		return nil
+	}
+
+	return &Schema{
+		TypeName:     b.TypeName,
+		Format:       b.Format,
+		Pattern:      b.Pattern,
+		Enum:         b.Enum,
+		EnumVarNames: b.EnumVarNames,
+		Example:      b.Example,
+		Item:         b.Item,
+		MinItems:     b.MinItems,
+		MaxItems:     b.MaxItems,
+	}
+}
+
+// mediaTypes builds the content map of request body or response, mime is used
+// when no MIME types are set for endpoint
+func mediaTypes(mimes []string, mime string, schema *Schema) map[string]*openAPI3MediaType {
+	if len(mimes) == 0 {
+		mimes = []string{mime}
+	}
+
+	content := make(map[string]*openAPI3MediaType, len(mimes))
+	for _, m := range mimes {
+		content[strings.TrimSpace(m)] = &openAPI3MediaType{}
+		if schema != nil {
+			content[strings.TrimSpace(m)].Schema = schema
+		}
+	}
+
+	return content
+}
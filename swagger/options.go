@@ -0,0 +1,58 @@
+package swagger
+
+import (
+	"strings"
+)
+
+// BuildOptions stores options of building the swagger description by routers
+type BuildOptions struct {
+	// Derive tags of endpoints from the path prefix of group/subrouter
+	AutoTags bool
+	// Fill host and schemes of served document from request
+	HostFromRequest bool
+}
+
+// NewBuildOptions applies options to default BuildOptions
+func NewBuildOptions(opts ...func(o *BuildOptions)) *BuildOptions {
+	o := &BuildOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// AutoTags enables deriving tags of endpoints without tags from the path prefix
+// of Echo group or Gorilla subrouter
+func AutoTags() func(o *BuildOptions) {
+	return func(o *BuildOptions) {
+		o.AutoTags = true
+	}
+}
+
+// HostFromRequest enables filling host and schemes of served document from
+// request at serve time, the headers X-Forwarded-Host and X-Forwarded-Proto set
+// by proxy are preferred
+func HostFromRequest() func(o *BuildOptions) {
+	return func(o *BuildOptions) {
+		o.HostFromRequest = true
+	}
+}
+
+// TagFromPrefix returns the tag for endpoint path (relative to base path) by
+// the path prefix of its group. If the group prefix is the base path, the first
+// static element of endpoint path is used.
+func TagFromPrefix(basePath, prefix, path string) string {
+	tag := strings.Trim(strings.TrimPrefix(prefix, basePath), "/")
+	if tag != "" && strings.HasPrefix(prefix, basePath) {
+		return tag
+	}
+
+	for _, el := range strings.Split(path, "/") {
+		if el == "" || strings.HasPrefix(el, "{") || strings.HasPrefix(el, ":") {
+			continue
+		}
+		return el
+	}
+
+	return ""
+}
@@ -44,8 +44,40 @@ func NewFileParameter(name, description string) *Parameter {
 }
 
 // Parse a parameter structure for JSON generation
-func (p *Parameter) Parse(sw Doc) {
-	ParseRootType(p, sw)
+func (p *Parameter) Parse(sw *Doc) {
+	sw.init()
+	sw.parseRequest(p)
+	if p.IN != InBody && p.IN != InFile {
+		p.flatten()
+	}
+}
+
+// flatten moves the schema of parameter in path, query, header or cookie to
+// the parameter, such parameters are described without schema in Swagger 2.0.
+// The schema of object is kept, it could be described in OpenAPI 3 only.
+func (p *Parameter) flatten() {
+	if p.Schema == nil || p.Schema.Ref != "" || p.Schema.Properties != nil {
+		return
+	}
+
+	if p.Schema.TypeName != "" {
+		p.TypeName = p.Schema.TypeName
+		p.Format = p.Schema.Format
+	}
+	if p.Schema.Enum != nil {
+		p.Enum, p.EnumVarNames = p.Schema.Enum, p.Schema.EnumVarNames
+	}
+	if p.Schema.Pattern != "" {
+		p.Pattern = p.Schema.Pattern
+	}
+	if p.Schema.Example != nil {
+		p.Example = p.Schema.Example
+	}
+	if p.Schema.Item != nil {
+		p.Item = p.Schema.Item
+		p.MinItems, p.MaxItems = p.Schema.MinItems, p.Schema.MaxItems
+	}
+	p.Schema = nil
 }
 
 func (p *Parameter) GetSchema() *Schema {
@@ -0,0 +1,170 @@
+package swagger
+
+import (
+	"reflect"
+	"sort"
+)
+
+type (
+	// Implementations describes the implementations of interface which are
+	// distinguished by the value of discriminator property
+	Implementations struct {
+		// Name of discriminator property
+		Discriminator string
+		// Types of implementations by values of discriminator
+		Types map[string]reflect.Type
+	}
+
+	// Discriminator of OpenAPI 3 schema, it maps the values of property to
+	// the schemas of implementations
+	Discriminator struct {
+		PropertyName string            `json:"propertyName"`
+		Mapping      map[string]string `json:"mapping,omitempty"`
+	}
+)
+
+// Dictionary with implementations of interfaces
+var interfaceDict = make(map[reflect.Type]*Implementations)
+
+// NewImplementations creates the description of implementations of interface,
+// the implementations are set by values of discriminator, e.g.
+// {"created": CreatedEvent{}, "deleted": DeletedEvent{}}
+func NewImplementations(discriminator string, implementations map[string]interface{}) *Implementations {
+	impls := &Implementations{
+		Discriminator: discriminator,
+		Types:         make(map[string]reflect.Type, len(implementations)),
+	}
+	for value, impl := range implementations {
+		t, _ := derefType(reflect.TypeOf(impl), reflect.Value{})
+		impls.Types[value] = t
+	}
+	return impls
+}
+
+// RegisterInterface registers the implementations of named interface for all
+// documents, the implementations must be named structures, e.g.
+// RegisterInterface(reflect.TypeOf((*Event)(nil)).Elem(), "type", map[string]interface{}{"created": CreatedEvent{}})
+func RegisterInterface(iface reflect.Type, discriminator string, implementations map[string]interface{}) {
+	typeDictMu.Lock()
+	defer typeDictMu.Unlock()
+
+	interfaceDict[iface] = NewImplementations(discriminator, implementations)
+	invalidateDefinitions()
+}
+
+// lookupInterface returns the implementations of interface registered for
+// document or for all documents, the document registry is preferred
+func (s *Doc) lookupInterface(t reflect.Type) (*Implementations, bool) {
+	if impls, ok := s.Interfaces[t]; ok {
+		return impls, true
+	}
+
+	typeDictMu.RLock()
+	defer typeDictMu.RUnlock()
+
+	impls, ok := interfaceDict[t]
+	return impls, ok
+}
+
+// values returns the sorted values of discriminator
+func (impls *Implementations) values() []string {
+	values := make([]string, 0, len(impls.Types))
+	for value := range impls.Types {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// parseInterface returns the reference to definition of interface registered
+// with implementations, the empty reference is returned for unregistered one.
+// In Swagger 2.0 the definition of interface contains the discriminator and
+// the implementations extend it by allOf. In OpenAPI 3 the definition of
+// interface is oneOf implementations with discriminator mapping, the
+// implementations contain the discriminator property.
+func parseInterface(t reflect.Type, sw *Doc) (ref string) {
+	impls, ok := sw.lookupInterface(t)
+	if !ok {
+		return ""
+	}
+
+	name := sw.modelName(t)
+	ref = sw.definitionRef(name)
+	sw.addDependency(t, true, ref)
+	if _, ok := sw.Definitions[name]; ok {
+		return ref
+	}
+
+	d := &Definition{}
+	sw.Definitions[name] = d
+
+	values := impls.values()
+
+	if sw.IsOpenAPI3() {
+		discriminator := &Discriminator{
+			PropertyName: impls.Discriminator,
+			Mapping:      make(map[string]string, len(values)),
+		}
+		for _, value := range values {
+			implRef := parseImplementation(impls.Types[value], sw)
+			if implRef == "" {
+				continue
+			}
+			d.OneOf = append(d.OneOf, &Property{BaseObject: BaseObject{Ref: implRef}})
+			discriminator.Mapping[value] = implRef
+			addDiscriminator(sw.Definitions[sw.modelName(impls.Types[value])], impls.Discriminator, value)
+		}
+		d.Discriminator = discriminator
+		return ref
+	}
+
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+
+	d.TypeName = constObject
+	d.Discriminator = impls.Discriminator
+	d.Properties = MapProperty{
+		impls.Discriminator: {BaseObject: BaseObject{TypeName: "string", Enum: enum}},
+	}
+	d.Required = []string{impls.Discriminator}
+
+	for _, value := range values {
+		if parseImplementation(impls.Types[value], sw) == "" {
+			continue
+		}
+		impl := sw.Definitions[sw.modelName(impls.Types[value])]
+		impl.AllOf = append([]*Property{{BaseObject: BaseObject{Ref: ref}}}, impl.AllOf...)
+		impl.DiscriminatorValue = value
+	}
+
+	return ref
+}
+
+// addDiscriminator adds the required discriminator property with the value of
+// implementation to its definition. The properties and the required ones are
+// copied, they could be shared with the cached definition.
+func addDiscriminator(d *Definition, discriminator, value string) {
+	properties := make(MapProperty, len(d.Properties)+1)
+	for name, p := range d.Properties {
+		properties[name] = p
+	}
+	properties[discriminator] = &Property{BaseObject: BaseObject{TypeName: "string", Enum: []interface{}{value}}}
+	d.Properties = properties
+
+	if hasTagOption(d.Required, discriminator) {
+		return
+	}
+	d.Required = append(d.Required[:len(d.Required):len(d.Required)], discriminator)
+}
+
+// parseImplementation returns the reference to definition of implementation,
+// only named structures are described by definitions
+func parseImplementation(t reflect.Type, sw *Doc) (ref string) {
+	if t.Kind() != reflect.Struct || t.Name() == "" {
+		return ""
+	}
+	ref, _ = parseInterfaceOrStruct(t, reflect.Value{}, sw)
+	return ref
+}
@@ -0,0 +1,26 @@
+package swagger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscriminatorOfImplementations(t *testing.T) {
+	for _, version := range []string{OpenAPI30, OpenAPI31} {
+		doc := goldenDoc(version)
+		for value, name := range map[string]string{"cat": "Cat", "dog": "Dog"} {
+			d := doc.Definitions[name]
+			kind, ok := d.Properties["kind"]
+			if !ok {
+				t.Errorf("%s: definition %s has no discriminator property", version, name)
+				continue
+			}
+			if !reflect.DeepEqual(kind.Enum, []interface{}{value}) {
+				t.Errorf("%s: discriminator of %s is %v", version, name, kind.Enum)
+			}
+			if !hasTagOption(d.Required, "kind") {
+				t.Errorf("%s: discriminator of %s is not required: %v", version, name, d.Required)
+			}
+		}
+	}
+}
@@ -7,6 +7,38 @@ import (
 
 type Response struct {
 	*BaseObject
+	// Headers sent with the response
+	Headers map[string]*Header `json:"headers,omitempty"`
+}
+
+// Header describes a header sent with the response
+type Header struct {
+	Description string `json:"description,omitempty"`
+	// Type name
+	TypeName string `json:"type"`
+	// Header format, for example: int64 is integer with format int64
+	Format string `json:"format,omitempty"`
+}
+
+// NewHeader creates a header of response, the format of kind could be overridden
+func NewHeader(description string, t reflect.Kind, format string) *Header {
+	typeName, kindFormat := ParseKind(t)
+	if format == "" {
+		format = kindFormat
+	}
+	return &Header{
+		Description: description,
+		TypeName:    typeName,
+		Format:      format,
+	}
+}
+
+// AddHeader adds a header to the response
+func (r *Response) AddHeader(name string, h *Header) {
+	if r.Headers == nil {
+		r.Headers = make(map[string]*Header)
+	}
+	r.Headers[name] = h
 }
 
 func NewResponse(description string) *Response {
@@ -15,8 +47,12 @@ func NewResponse(description string) *Response {
 	}
 }
 
-// Parse a response structure for JSON generation
-func (r *Response) Parse(sw Doc) {
+// Parse a response structure for JSON generation, the response added without
+// schema has no body, so its empty schema is removed
+func (r *Response) Parse(sw *Doc) {
+	if r.Schema != nil && reflect.DeepEqual(*r.Schema, Schema{}) {
+		r.Schema = nil
+	}
 	ParseRootType(r, sw)
 }
 
@@ -13,14 +13,9 @@ const (
 	constArray   = "array"
 )
 
-type AdditionalProperties struct {
-	// Object format, for example: int64 is integer with format int64
-	Format string `json:"format,omitempty"`
-	// Reference to a schema definition
-	Ref string `json:"$ref,omitempty"`
-	// Type name
-	TypeName string `json:"type,omitempty"`
-}
+// AdditionalProperties is the schema of values of map, it could be nested
+// like any other schema
+type AdditionalProperties = BaseObject
 
 type Schema struct {
 	// Object format, for example: int64 is integer with format int64
@@ -29,10 +24,29 @@ type Schema struct {
 	Ref string `json:"$ref,omitempty"`
 	// Type name
 	TypeName string `json:"type,omitempty"`
+	// Pattern of string value
+	Pattern string `json:"pattern,omitempty"`
+	// Example of value
+	Example interface{} `json:"example,omitempty"`
+	// List of enumeration values
+	Enum []interface{} `json:"enum,omitempty"`
+	// Names of constants of enumeration values
+	EnumVarNames []string `json:"x-enum-varnames,omitempty"`
+	// Could it be null?
+	Nullable bool `json:"x-nullable,omitempty"`
+	// Is it free-form value encoded by custom MarshalJSON?
+	FreeForm bool `json:"x-free-form,omitempty"`
 	//
 	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
 	//
 	Item *BaseObject `json:"items,omitempty"`
+	// Count of items of fixed-size array
+	MinItems *int64 `json:"minItems,omitempty"`
+	MaxItems *int64 `json:"maxItems,omitempty"`
+	// List of properties of inline object
+	Properties MapProperty `json:"properties,omitempty"`
+	// List of required properties of inline object
+	Required []string `json:"required,omitempty"`
 	//
 	Type interface{} `json:"-"`
 }
@@ -50,9 +64,37 @@ type Schemater interface {
 	SetFormat(string)
 }
 
+// property returns the property described by schema
+func (s *Schema) property() *Property {
+	return &Property{
+		BaseObject: BaseObject{
+			TypeName:             s.TypeName,
+			Format:               s.Format,
+			Pattern:              s.Pattern,
+			Example:              s.Example,
+			Enum:                 s.Enum,
+			EnumVarNames:         s.EnumVarNames,
+			Nullable:             s.Nullable,
+			FreeForm:             s.FreeForm,
+			Ref:                  s.Ref,
+			AdditionalProperties: s.AdditionalProperties,
+			Item:                 s.Item,
+			MinItems:             s.MinItems,
+			MaxItems:             s.MaxItems,
+			Properties:           s.Properties,
+			Required:             s.Required,
+		},
+	}
+}
+
 // ParseRootType is a method for analyzing Types and Schemas of Parameters and
 // Response
-func ParseRootType(obj Schemater, sw Doc) {
+func ParseRootType(obj Schemater, sw *Doc) {
+	sw.init()
+	parseRootType(obj, sw)
+}
+
+func parseRootType(obj Schemater, sw *Doc) {
 	// Parse Type when Schema unused
 	if obj.GetSchema() == nil {
 		TypeName, Format := ParseKind(obj.GetType())
@@ -61,26 +103,59 @@ func ParseRootType(obj Schemater, sw Doc) {
 		return
 	}
 
-	value := valueFromPtr(obj.GetSchema().Type)
+	// Parse Schema when it is reflect.Kind type
+	if _, ok := obj.GetSchema().Type.(reflect.Kind); ok || obj.GetSchema().Type == nil {
+		TypeName, Format := ParseKind(obj.GetSchema().Type)
+		obj.SetTypeName(TypeName)
+		obj.SetFormat(Format)
+		return
+	}
+
+	t, v := derefType(reflect.TypeOf(obj.GetSchema().Type), reflect.ValueOf(obj.GetSchema().Type))
+
+	// Parse Schema, when it is registered type or type which describes itself
+	if custom := sw.typeSchema(t); custom != nil {
+		schema := *custom
+		schema.Type = obj.GetSchema().Type
+		*obj.GetSchema() = schema
+		obj.SetTypeName(schema.TypeName)
+		obj.SetFormat(schema.Format)
+		return
+	}
 
 	// Parse Schema, when it is Structure or Pointer to structure
-	switch reflect.ValueOf(value).Kind() {
+	switch t.Kind() {
 	case reflect.Struct:
+		ref, inline := parseInterfaceOrStruct(t, v, sw)
 		obj.GetSchema().TypeName = ""
-		obj.GetSchema().Ref = parseInterfaceOrStruct(value, &sw)
+		obj.GetSchema().Ref = ref
+		if inline != nil {
+			obj.GetSchema().TypeName = inline.TypeName
+			obj.GetSchema().Properties = inline.Properties
+			obj.GetSchema().Required = inline.Required
+		}
 		return
 	case reflect.Slice, reflect.Array:
 		obj.GetSchema().TypeName = constArray
-		obj.GetSchema().Item = parseArrayOrSlice(value, &sw)
+		obj.GetSchema().Item = parseArrayOrSlice(t, sw)
+		if t.Kind() == reflect.Array {
+			obj.GetSchema().MinItems, obj.GetSchema().MaxItems = arrayLen(t)
+		}
 		return
 	case reflect.Map:
 		obj.GetSchema().TypeName = constObject
-		obj.GetSchema().AdditionalProperties = parseMap(value, &sw)
+		obj.GetSchema().AdditionalProperties = parseMap(t, sw)
 		return
+	case reflect.Interface:
+		if ref := parseInterface(t, sw); ref != "" {
+			obj.GetSchema().TypeName = ""
+			obj.GetSchema().Ref = ref
+			return
+		}
 	}
 
-	// Parse Schema when it is reflect.Kind type
-	TypeName, Format := ParseKind(value)
+	// Parse Schema when it is value of simple type
+	TypeName, Format := ParseKind(t.Kind())
 	obj.SetTypeName(TypeName)
 	obj.SetFormat(Format)
 }
@@ -97,7 +172,7 @@ func ParseKind(kind interface{}) (typeName, format string) {
 		typeName = constNumber
 	case reflect.Bool:
 		typeName = constBoolean
-	case reflect.Invalid, reflect.Struct:
+	case nil, reflect.Invalid, reflect.Struct:
 		// No changes if type not setted
 	case reflect.Interface, reflect.Map:
 		typeName = constObject
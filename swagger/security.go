@@ -0,0 +1,143 @@
+package swagger
+
+import (
+	"encoding/json"
+)
+
+// Types of security schemes
+const (
+	SecurityAPIKey = "apiKey"
+	SecurityBasic  = "basic"
+	SecurityOAuth2 = "oauth2"
+)
+
+// Flows of OAuth2 security scheme
+const (
+	OAuth2Implicit    = "implicit"
+	OAuth2Password    = "password"
+	OAuth2Application = "application"
+	OAuth2AccessCode  = "accessCode"
+)
+
+type (
+	// Description of security scheme used by endpoints
+	SecurityScheme struct {
+		// Type of security scheme: apiKey, basic or oauth2
+		Type        string `json:"type"`
+		Description string `json:"description,omitempty"`
+		// Name of header or query parameter with API key
+		Name string `json:"name,omitempty"`
+		// Location of API key, in header or in query
+		IN InType `json:"in,omitempty"`
+		// OAuth2 flow: implicit, password, application or accessCode
+		Flow             string `json:"flow,omitempty"`
+		AuthorizationURL string `json:"authorizationUrl,omitempty"`
+		TokenURL         string `json:"tokenUrl,omitempty"`
+		// Available scopes for OAuth2 security scheme
+		Scopes map[string]string `json:"-"`
+	}
+
+	// SecurityRequirement is a map of names of security schemes to the list of
+	// required scopes, all schemes in requirement must be satisfied
+	SecurityRequirement map[string][]string
+
+	// SecurityRequirements is a list of alternative security requirements, the
+	// empty list describes the anonymous endpoint
+	SecurityRequirements []SecurityRequirement
+)
+
+type ISecurityScheme interface {
+	SetDescription(d string) ISecurityScheme
+	AddScope(scope, description string) ISecurityScheme
+}
+
+// NewAPIKeySecurity creates API key security scheme, the key is passed in header
+// or in query parameter with name
+func NewAPIKeySecurity(name string, in InType) ISecurityScheme {
+	return &SecurityScheme{
+		Type: SecurityAPIKey,
+		Name: name,
+		IN:   in,
+	}
+}
+
+// NewBasicSecurity creates basic authentication security scheme
+func NewBasicSecurity() ISecurityScheme {
+	return &SecurityScheme{
+		Type: SecurityBasic,
+	}
+}
+
+// NewOAuth2Security creates OAuth2 security scheme, authorizationURL is used by
+// implicit and accessCode flows, tokenURL is used by password, application and
+// accessCode flows
+func NewOAuth2Security(flow, authorizationURL, tokenURL string) ISecurityScheme {
+	return &SecurityScheme{
+		Type:             SecurityOAuth2,
+		Flow:             flow,
+		AuthorizationURL: authorizationURL,
+		TokenURL:         tokenURL,
+		Scopes:           make(map[string]string),
+	}
+}
+
+func (s *SecurityScheme) SetDescription(d string) ISecurityScheme {
+	if s == nil {
+		return nil
+	}
+	s.Description = d
+	return s
+}
+
+func (s *SecurityScheme) AddScope(scope, description string) ISecurityScheme {
+	if s == nil {
+		return nil
+	}
+	if s.Scopes == nil {
+		s.Scopes = make(map[string]string)
+	}
+	s.Scopes[scope] = description
+	return s
+}
+
+// MarshalJSON emits the scopes of OAuth2 security scheme only, the empty scopes
+// are emitted as {} because the field is required by specification
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	type securityScheme SecurityScheme
+	if s.Type != SecurityOAuth2 {
+		return json.Marshal(securityScheme(s))
+	}
+
+	scopes := s.Scopes
+	if scopes == nil {
+		scopes = make(map[string]string)
+	}
+	return json.Marshal(struct {
+		securityScheme
+		Scopes map[string]string `json:"scopes"`
+	}{securityScheme(s), scopes})
+}
+
+// NewSecurityRequirement creates the requirement of security scheme with scopes,
+// other schemes are added to the requirement by Add
+func NewSecurityRequirement(name string, scopes ...string) SecurityRequirement {
+	return SecurityRequirement{}.Add(name, scopes...)
+}
+
+// Add adds the security scheme with scopes to requirement, all schemes of
+// requirement must be satisfied
+func (r SecurityRequirement) Add(name string, scopes ...string) SecurityRequirement {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	r[name] = scopes
+	return r
+}
+
+// Append adds an alternative requirement of security scheme with scopes
+func (sr *SecurityRequirements) Append(name string, scopes ...string) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	*sr = append(*sr, SecurityRequirement{name: scopes})
+}
@@ -0,0 +1,153 @@
+package swagger
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Formats of served swagger document
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// requestedFormat returns the format of document requested by client. The format
+// is selected by the name of document (doc.json or doc.yaml), it could be
+// overridden by the query parameter "format" or by the Accept header. The
+// byAccept reports whether the format is selected by the Accept header.
+func requestedFormat(r *http.Request, docName string) (format string, byAccept bool) {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case formatYAML, "yml":
+		return formatYAML, false
+	case formatJSON:
+		return formatJSON, false
+	}
+
+	if strings.HasSuffix(docName, ".yaml") {
+		return formatYAML, false
+	}
+	if strings.Contains(r.Header.Get("Accept"), formatYAML) {
+		return formatYAML, true
+	}
+
+	return formatJSON, true
+}
+
+// requestHost returns the host and the scheme of request, the headers
+// X-Forwarded-Host and X-Forwarded-Proto set by proxy are preferred. The
+// forwarded values with invalid syntax are ignored.
+func requestHost(r *http.Request) (host, scheme string) {
+	host = r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		if h := strings.TrimSpace(strings.Split(forwarded, ",")[0]); validHost(h) {
+			host = h
+		}
+	}
+
+	scheme = "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		switch s := strings.ToLower(strings.TrimSpace(strings.Split(forwarded, ",")[0])); s {
+		case "http", "https":
+			scheme = s
+		}
+	}
+
+	return host, scheme
+}
+
+// Allowed host name, each label contains letters, digits, hyphens and
+// underscores
+var hostNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?)*\.?$`)
+
+// validHost reports whether the value is the host name or IP with optional port
+func validHost(value string) bool {
+	host := value
+	if h, port, err := net.SplitHostPort(value); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return false
+		}
+		host = h
+	} else if strings.Contains(value, ":") {
+		return false
+	}
+
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	return len(host) <= 253 && hostNameRegexp.MatchString(host)
+}
+
+// readDocWithHost reads swagger document with replaced host and scheme, the
+// empty scheme keeps the schemes of document
+func readDocWithHost(name, host, scheme string) (string, error) {
+	swaggerMu.RLock()
+	d, ok := swag[name].(*Doc)
+	swaggerMu.RUnlock()
+
+	if !ok {
+		return ReadDoc(name)
+	}
+
+	doc := *d
+	doc.Host = host
+	if scheme != "" {
+		doc.Schemes = []string{scheme}
+	}
+	return doc.ReadDoc(), nil
+}
+
+// documentHost returns the host of registered swagger document
+func documentHost(name string) string {
+	swaggerMu.RLock()
+	defer swaggerMu.RUnlock()
+
+	if d, ok := swag[name].(*Doc); ok {
+		return d.Host
+	}
+	return ""
+}
+
+// ServeDoc writes swagger document in the format requested by client
+func ServeDoc(w http.ResponseWriter, r *http.Request, docName string, config *Config) (err error) {
+	var doc string
+	switch {
+	case config.HostFromRequest:
+		host, scheme := requestHost(r)
+		doc, err = readDocWithHost(config.Name, host, scheme)
+	case documentHost(config.Name) == "":
+		// The server listens on address without host, the host of request is
+		// used by clients
+		doc, err = readDocWithHost(config.Name, r.Host, "")
+	default:
+		doc, err = ReadDoc(config.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	format, byAccept := requestedFormat(r, docName)
+	if byAccept {
+		// The caches keep the documents in different formats separately
+		w.Header().Add("Vary", "Accept")
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == formatYAML {
+		yamlData, err := jsonToYAML([]byte(doc))
+		if err != nil {
+			return err
+		}
+		doc = string(yamlData)
+		contentType = "application/yaml; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, err = w.Write([]byte(doc))
+	return err
+}
@@ -0,0 +1,118 @@
+package swagger
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidHost(t *testing.T) {
+	tests := []struct {
+		host  string
+		valid bool
+	}{
+		{"example.com", true},
+		{"example.com:8080", true},
+		{"api_v1.example.com.", true},
+		{"localhost", true},
+		{"127.0.0.1", true},
+		{"127.0.0.1:1323", true},
+		{"[::1]:1323", true},
+		{"::1", false},
+		{"", false},
+		{"example.com:", false},
+		{"example.com:0", false},
+		{"example.com:65536", false},
+		{"example.com:http", false},
+		{"example.com:80:80", false},
+		{"-example.com", false},
+		{"example-.com", false},
+		{"example..com", false},
+		{"example.com/path", false},
+		{"user@example.com", false},
+		{"example.com\r\nX-Injected: 1", false},
+		{"<script>", false},
+	}
+
+	for _, tt := range tests {
+		if valid := validHost(tt.host); valid != tt.valid {
+			t.Errorf("validHost(%q) = %v, want %v", tt.host, valid, tt.valid)
+		}
+	}
+}
+
+func TestRequestHost(t *testing.T) {
+	tests := []struct {
+		name           string
+		tls            bool
+		forwardedHost  string
+		forwardedProto string
+		host, scheme   string
+	}{
+		{name: "request", host: "api.test:1323", scheme: "http"},
+		{name: "tls", tls: true, host: "api.test:1323", scheme: "https"},
+		{name: "forwarded", forwardedHost: "example.com", forwardedProto: "https", host: "example.com", scheme: "https"},
+		{name: "forwarded by proxies", forwardedHost: " example.com:8443 , proxy.local", forwardedProto: "HTTPS, http",
+			host: "example.com:8443", scheme: "https"},
+		{name: "invalid host", forwardedHost: "example.com/evil", host: "api.test:1323", scheme: "http"},
+		{name: "invalid port", forwardedHost: "example.com:99999", host: "api.test:1323", scheme: "http"},
+		{name: "invalid first host of list", forwardedHost: "evil host, example.com", host: "api.test:1323", scheme: "http"},
+		{name: "scheme other than http", forwardedProto: "javascript", host: "api.test:1323", scheme: "http"},
+		{name: "scheme other than https over tls", tls: true, forwardedProto: "ws", host: "api.test:1323", scheme: "https"},
+		{name: "empty scheme of list", forwardedProto: ", https", host: "api.test:1323", scheme: "http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://api.test:1323/swagger/doc.json", nil)
+			if tt.tls {
+				r.TLS = &tls.ConnectionState{}
+			}
+			if tt.forwardedHost != "" {
+				r.Header.Set("X-Forwarded-Host", tt.forwardedHost)
+			}
+			if tt.forwardedProto != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			}
+
+			host, scheme := requestHost(r)
+			if host != tt.host || scheme != tt.scheme {
+				t.Errorf("requestHost() = %q, %q, want %q, %q", host, scheme, tt.host, tt.scheme)
+			}
+		})
+	}
+}
+
+func TestServeDocVary(t *testing.T) {
+	Register("serve-test", NewDoc(NewSwagger().(*BaseAPI)))
+
+	tests := []struct {
+		target, docName, accept string
+		yaml, vary              bool
+	}{
+		{target: "/swagger/doc.json", docName: "doc.json", vary: true},
+		{target: "/swagger/doc.json", docName: "doc.json", accept: "application/yaml", yaml: true, vary: true},
+		{target: "/swagger/doc.json?format=yaml", docName: "doc.json", accept: "application/json", yaml: true},
+		{target: "/swagger/doc.json?format=json", docName: "doc.json", accept: "application/yaml"},
+		{target: "/swagger/doc.yaml", docName: "doc.yaml", accept: "application/json", yaml: true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "http://api.test"+tt.target, nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		if err := ServeDoc(w, r, tt.docName, &Config{Name: "serve-test"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if yaml := strings.HasPrefix(w.Header().Get("Content-Type"), "application/yaml"); yaml != tt.yaml {
+			t.Errorf("%s, Accept %q: content type %q", tt.target, tt.accept, w.Header().Get("Content-Type"))
+		}
+		if vary := w.Header().Get("Vary") == "Accept"; vary != tt.vary {
+			t.Errorf("%s, Accept %q: Vary %q", tt.target, tt.accept, w.Header().Get("Vary"))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package swagger
+
+import (
+	"reflect"
+)
+
+// Suffix of name of definition describing the type in requests
+const requestModelSuffix = "Input"
+
+// RecordModels records the types of in-body parameters and responses of method.
+// If splitting of models is enabled, only the types used both in requests and
+// in responses are described by separate definitions, so the builders record
+// the models of all endpoints before parsing them.
+func (s *Doc) RecordModels(m *Method) {
+	if m == nil || !s.SplitModels {
+		return
+	}
+	s.init()
+
+	for _, p := range m.Parameters {
+		if p.IN == InBody && p.Schema != nil {
+			s.recordModel(p.Schema.Type, s.requestTypes)
+		}
+	}
+	for _, r := range m.Responses {
+		if r.Schema != nil {
+			s.recordModel(r.Schema.Type, s.responseTypes)
+		}
+	}
+}
+
+// recordModel records the type of value and the types nested in it
+func (s *Doc) recordModel(value interface{}, types map[reflect.Type]bool) {
+	if _, ok := value.(reflect.Kind); ok || value == nil {
+		return
+	}
+	s.recordType(reflect.TypeOf(value), types)
+}
+
+// recordType records the type, its fields, items of containers and registered
+// implementations of interfaces
+func (s *Doc) recordType(t reflect.Type, types map[reflect.Type]bool) {
+	t, _ = derefType(t, reflect.Value{})
+	if types[t] {
+		return
+	}
+	types[t] = true
+
+	if s.typeSchema(t) != nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Anonymous {
+				s.recordType(t.Field(i).Type, types)
+			}
+		}
+		for _, f := range cachedFields(t) {
+			s.recordType(f.field.Type, types)
+		}
+	case reflect.Array, reflect.Slice, reflect.Map:
+		s.recordType(t.Elem(), types)
+	case reflect.Interface:
+		if impls, ok := s.lookupInterface(t); ok {
+			for _, impl := range impls.Types {
+				s.recordType(impl, types)
+			}
+		}
+	}
+}
+
+// parseRequest parses the type of request parameter, the definitions of types
+// are built for requests while it is parsed
+func (s *Doc) parseRequest(obj Schemater) {
+	request := s.request
+	s.request = true
+	defer func() { s.request = request }()
+
+	parseRootType(obj, s)
+}
+
+// isSplit reports whether the type is described by separate definitions for
+// requests and for responses. Such type is used both in requests and in
+// responses and it has read-only or write-only properties itself or in nested
+// structures.
+func (s *Doc) isSplit(t reflect.Type) bool {
+	return s.SplitModels && s.requestTypes[t] && s.responseTypes[t] &&
+		s.hasReadWriteOnly(t, make(map[reflect.Type]bool))
+}
+
+// modelName returns the name of definition of type, the type split for requests
+// and responses has separate name in requests
+func (s *Doc) modelName(t reflect.Type) string {
+	if s.request && s.isSplit(t) {
+		return s.requestDefinitionName(t)
+	}
+	return s.definitionName(t)
+}
+
+// hasReadWriteOnly reports whether the type has read-only or write-only
+// properties itself or in nested structures, containers and registered
+// implementations of interfaces
+func (s *Doc) hasReadWriteOnly(t reflect.Type, visited map[reflect.Type]bool) bool {
+	t, _ = derefType(t, reflect.Value{})
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	if s.typeSchema(t) != nil {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for _, f := range cachedFields(t) {
+			if f.tags.ReadOnly || f.tags.WriteOnly || s.hasReadWriteOnly(f.field.Type, visited) {
+				return true
+			}
+		}
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return s.hasReadWriteOnly(t.Elem(), visited)
+	case reflect.Interface:
+		if impls, ok := s.lookupInterface(t); ok {
+			for _, impl := range impls.Types {
+				if s.hasReadWriteOnly(impl, visited) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// skipField reports whether the property is omitted from the definition of
+// split type describing it in requests or in responses
+func (s *Doc) skipField(tags *Property) bool {
+	if s.request {
+		return tags.ReadOnly
+	}
+	return tags.WriteOnly
+}
@@ -3,6 +3,8 @@ package swagger
 import (
 	"encoding/json"
 	"errors"
+	"net"
+	"reflect"
 	"strings"
 	"sync"
 )
@@ -12,8 +14,36 @@ type (
 	BaseAPI struct {
 		Version string `json:"swagger,omitempty"`
 		Info    IInfo  `json:"info,omitempty"`
+		// Host (name or IP and port) serving the API
+		Host string `json:"host,omitempty"`
 		// Base path to API, it consist the prefix of endpoint path
 		BasePath string `json:"basePath,omitempty"`
+		// Transfer protocols of the API: http, https, ws, wss
+		Schemes []string `json:"schemes,omitempty"`
+		// Security schemes available for endpoints
+		SecurityDefinitions map[string]ISecurityScheme `json:"securityDefinitions,omitempty"`
+		// Default security requirements for all endpoints
+		Security SecurityRequirements `json:"security,omitempty"`
+		// List of tags with additional metadata, the order of tags is used by UI
+		Tags []ITag `json:"tags,omitempty"`
+		// Function for naming of definitions, if it is nil the name of type is
+		// used and it is qualified by package on collision
+		DefinitionNamer DefinitionNamer `json:"-"`
+		// Schemas of types registered for the document, they are preferred to
+		// the types registered by RegisterType
+		Types map[reflect.Type]TypeDictElement `json:"-"`
+		// Implementations of interfaces registered for the document, they are
+		// preferred to the implementations registered by RegisterInterface
+		Interfaces map[reflect.Type]*Implementations `json:"-"`
+		// Allowed values of types registered for the document, they are
+		// preferred to the values registered by RegisterEnum
+		Enums map[reflect.Type][]EnumValue `json:"-"`
+		// Compose the structures embedded by value by allOf instead of
+		// promoting their fields
+		EmbeddedAllOf bool `json:"-"`
+		// Describe the types with read-only or write-only properties by
+		// separate definitions for requests and for responses
+		SplitModels bool `json:"-"`
 	}
 	// High level object for describing the builded API
 	Doc struct {
@@ -22,6 +52,19 @@ type (
 		Paths map[string]Methods `json:"paths,omitempty"`
 		// List of definitions
 		Definitions map[string]*Definition `json:"definitions,omitempty"`
+		// Types of definitions by names and names of definitions by types,
+		// used for detecting different types with the same name
+		definitionTypes map[string]modelKey
+		definitionNames map[modelKey]string
+		// Are the types parsed for request? It is set while parsing the
+		// parameters by parseRequest
+		request bool
+		// Types used in requests and in responses, they are recorded by
+		// RecordModels
+		requestTypes  map[reflect.Type]bool
+		responseTypes map[reflect.Type]bool
+		// Stack of definitions which are being built
+		builds *[]*definitionBuild
 	}
 	// Information about the created swagger
 	Info struct {
@@ -41,9 +84,53 @@ type (
 		Name string `json:"name,omitempty"`
 		URL  string `json:"url,omitempty"`
 	}
+	// Tag used for grouping of endpoints
+	Tag struct {
+		Name         string        `json:"name"`
+		Description  string        `json:"description,omitempty"`
+		ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+	}
+	// Reference to external documentation
+	ExternalDocs struct {
+		Description string `json:"description,omitempty"`
+		URL         string `json:"url"`
+	}
 )
 
-type ISwaggerAPI interface{}
+// Versions of the specification supported by Doc
+const (
+	Swagger20 = "2.0"
+	OpenAPI30 = "3.0.3"
+	OpenAPI31 = "3.1.0"
+)
+
+type ISwaggerAPI interface {
+	SetSpecVersion(v string) ISwaggerAPI
+	// AddSecurityDefinition - adds a security scheme available for endpoints
+	AddSecurityDefinition(name string, scheme ISecurityScheme) ISwaggerAPI
+	// AddSecurity - adds an alternative security requirement for all endpoints
+	AddSecurity(name string, scopes ...string) ISwaggerAPI
+	// AddSecurityRequirement - adds an alternative security requirement with several schemes for all endpoints
+	AddSecurityRequirement(requirement SecurityRequirement) ISwaggerAPI
+	// AddTag - adds a tag with metadata
+	AddTag(t ITag) ISwaggerAPI
+	// SetHost - sets the host (name or IP and port) serving the API
+	SetHost(h string) ISwaggerAPI
+	// SetSchemes - sets the transfer protocols of the API
+	SetSchemes(schemes ...string) ISwaggerAPI
+	// SetDefinitionNamer - sets the function for naming of definitions
+	SetDefinitionNamer(n DefinitionNamer) ISwaggerAPI
+	// RegisterType - registers the schema of type for the document
+	RegisterType(t reflect.Type, e TypeDictElement) ISwaggerAPI
+	// RegisterInterface - registers the implementations of interface for the document
+	RegisterInterface(iface reflect.Type, discriminator string, implementations map[string]interface{}) ISwaggerAPI
+	// RegisterEnum - registers the allowed values of type for the document
+	RegisterEnum(t reflect.Type, values ...EnumValue) ISwaggerAPI
+	// SetEmbeddedAllOf - enables composing of embedded structures by allOf
+	SetEmbeddedAllOf(allOf bool) ISwaggerAPI
+	// SetSplitModels - enables splitting of models to request and response definitions
+	SetSplitModels(split bool) ISwaggerAPI
+}
 
 type BasePather interface {
 	SetBasePath(p string) Informer
@@ -55,7 +142,36 @@ type Informer interface {
 
 func NewSwagger() BasePather {
 	return &BaseAPI{
-		Version: "2.0",
+		Version: Swagger20,
+	}
+}
+
+// NewDoc creates the document for describing endpoints of API
+func NewDoc(api *BaseAPI) *Doc {
+	s := &Doc{BaseAPI: *api}
+	s.init()
+	return s
+}
+
+// init creates the maps of document which are not set, so the document
+// declared by literal could be used too
+func (s *Doc) init() {
+	if s.Paths == nil {
+		s.Paths = make(map[string]Methods)
+	}
+	if s.Definitions == nil {
+		s.Definitions = make(map[string]*Definition)
+	}
+	if s.definitionTypes == nil {
+		s.definitionTypes = make(map[string]modelKey)
+		s.definitionNames = make(map[modelKey]string)
+	}
+	if s.requestTypes == nil {
+		s.requestTypes = make(map[reflect.Type]bool)
+		s.responseTypes = make(map[reflect.Type]bool)
+	}
+	if s.builds == nil {
+		s.builds = new([]*definitionBuild)
 	}
 }
 
@@ -63,6 +179,32 @@ func (s *Doc) ReadDoc() string {
 	return string(s.JSON())
 }
 
+// YAML returns the document in YAML format
+func (s *Doc) YAML() (yamlData []byte) {
+	yamlData, err := jsonToYAML(s.JSON())
+	if err != nil {
+		return
+	}
+	return
+}
+
+// MarshalJSON encodes the document in the layout of its specification version
+func (s *Doc) MarshalJSON() ([]byte, error) {
+	if s.IsOpenAPI3() {
+		return json.Marshal(s.openAPI3())
+	}
+	type swagger20 Doc
+	return json.Marshal((*swagger20)(s))
+}
+
+// definitionRef returns the reference to the named definition
+func (s *Doc) definitionRef(name string) string {
+	if s.IsOpenAPI3() {
+		return "#/components/schemas/" + name
+	}
+	return "#/definitions/" + name
+}
+
 func (s *Doc) JSON() (jsonData []byte) {
 	jsonData, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
@@ -73,10 +215,20 @@ func (s *Doc) JSON() (jsonData []byte) {
 
 func (s *BaseAPI) NewSwagger() BasePather {
 	return &BaseAPI{
-		Version: "2.0",
+		Version: Swagger20,
 	}
 }
 
+// IsOpenAPI3 reports whether the document is emitted in the OpenAPI 3 layout
+func (s *BaseAPI) IsOpenAPI3() bool {
+	return strings.HasPrefix(s.Version, "3.")
+}
+
+// IsOpenAPI31 reports whether the schemas are emitted in JSON Schema 2020-12 dialect
+func (s *BaseAPI) IsOpenAPI31() bool {
+	return strings.HasPrefix(s.Version, "3.1")
+}
+
 func (s *BaseAPI) SetBasePath(p string) Informer {
 	if s == nil {
 		return nil
@@ -93,6 +245,137 @@ func (s *BaseAPI) SetInfo(i IInfo) ISwaggerAPI {
 	return s
 }
 
+// SetSpecVersion selects the specification version of the document, e.g. Swagger20, OpenAPI30 or OpenAPI31
+func (s *BaseAPI) SetSpecVersion(v string) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Version = v
+	return s
+}
+
+func (s *BaseAPI) AddSecurityDefinition(name string, scheme ISecurityScheme) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	if s.SecurityDefinitions == nil {
+		s.SecurityDefinitions = make(map[string]ISecurityScheme)
+	}
+	s.SecurityDefinitions[name] = scheme
+	return s
+}
+
+func (s *BaseAPI) AddSecurity(name string, scopes ...string) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Security.Append(name, scopes...)
+	return s
+}
+
+func (s *BaseAPI) AddSecurityRequirement(requirement SecurityRequirement) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Security = append(s.Security, requirement)
+	return s
+}
+
+func (s *BaseAPI) AddTag(t ITag) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Tags = append(s.Tags, t)
+	return s
+}
+
+func (s *BaseAPI) SetHost(h string) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Host = h
+	return s
+}
+
+func (s *BaseAPI) SetSchemes(schemes ...string) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.Schemes = schemes
+	return s
+}
+
+func (s *BaseAPI) SetDefinitionNamer(n DefinitionNamer) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.DefinitionNamer = n
+	return s
+}
+
+func (s *BaseAPI) RegisterType(t reflect.Type, e TypeDictElement) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	if s.Types == nil {
+		s.Types = make(map[reflect.Type]TypeDictElement)
+	}
+	s.Types[t] = e
+	return s
+}
+
+func (s *BaseAPI) RegisterInterface(iface reflect.Type, discriminator string, implementations map[string]interface{}) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	if s.Interfaces == nil {
+		s.Interfaces = make(map[reflect.Type]*Implementations)
+	}
+	s.Interfaces[iface] = NewImplementations(discriminator, implementations)
+	return s
+}
+
+func (s *BaseAPI) RegisterEnum(t reflect.Type, values ...EnumValue) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	if s.Enums == nil {
+		s.Enums = make(map[reflect.Type][]EnumValue)
+	}
+	s.Enums[t] = values
+	return s
+}
+
+func (s *BaseAPI) SetEmbeddedAllOf(allOf bool) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.EmbeddedAllOf = allOf
+	return s
+}
+
+func (s *BaseAPI) SetSplitModels(split bool) ISwaggerAPI {
+	if s == nil {
+		return nil
+	}
+	s.SplitModels = split
+	return s
+}
+
+// HostFromAddress returns the host for address on which the server listens,
+// the address without host or with unspecified IP (":1323", "0.0.0.0:1323")
+// gives empty host, it is filled by the host of request at serve time.
+func HostFromAddress(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return ""
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		return ""
+	}
+	return address
+}
+
 type IInfo interface {
 	SetVersion(v string) IInfo
 	SetContact(c IContact) IInfo
@@ -227,6 +510,36 @@ func (c *Contact) SetEmail(e string) IContact {
 	return c
 }
 
+type ITag interface {
+	SetDescription(d string) ITag
+	SetExternalDocs(description, url string) ITag
+}
+
+func NewTag(name string) ITag {
+	return &Tag{
+		Name: name,
+	}
+}
+
+func (t *Tag) SetDescription(d string) ITag {
+	if t == nil {
+		return nil
+	}
+	t.Description = d
+	return t
+}
+
+func (t *Tag) SetExternalDocs(description, url string) ITag {
+	if t == nil {
+		return nil
+	}
+	t.ExternalDocs = &ExternalDocs{
+		Description: description,
+		URL:         url,
+	}
+	return t
+}
+
 var (
 	swaggerMu sync.RWMutex
 	swag      map[string]Swagger
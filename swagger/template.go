@@ -102,6 +102,8 @@ type Config struct {
 	URL string
 	// Name of swagger object
 	Name string
+	// Fill host and schemes of served document from request
+	HostFromRequest bool
 }
 
 // URL presents the url pointing to API definition (normally swagger.json or swagger.yaml).
@@ -0,0 +1,8 @@
+// Package billing contains the models for golden tests, its types have the
+// same names as the types of package swagger
+package billing
+
+type Invoice struct {
+	Number string  `json:"number" validate:"required"`
+	Amount float64 `json:"amount" validate:"min=0"`
+}
@@ -0,0 +1,158 @@
+package swagger
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchemaDescriber is implemented by types which describe their schema, e.g.
+// types with custom MarshalJSON. The method is called on pointer to zero value,
+// nil result means that the schema is built as for other types.
+type SchemaDescriber interface {
+	SwaggerSchema(sw *Doc) *Schema
+}
+
+var (
+	schemaDescriberType = reflect.TypeOf((*SchemaDescriber)(nil)).Elem()
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// TypeDictElement is the schema of type registered in dictionary of types, it
+// replaces the schema built by reflection
+type TypeDictElement struct {
+	TypeName string
+	Format   string
+	Pattern  string
+	Example  interface{}
+	Nullable bool
+	// Arbitrary schema of type, e.g. object with properties, it replaces other
+	// fields of element
+	Schema *Schema
+}
+
+var (
+	typeDictMu sync.RWMutex
+	// Dictionary with self-types
+	typeDict = map[reflect.Type]TypeDictElement{
+		reflect.TypeOf(time.Time{}): {
+			TypeName: "string",
+			Format:   "date-time",
+		},
+		reflect.TypeOf(uuid.UUID{}): {
+			TypeName: "string",
+			Format:   "uuid",
+		},
+		reflect.TypeOf(sql.NullTime{}): {
+			TypeName: "string",
+			Format:   "date-time",
+			Nullable: true,
+		},
+		reflect.TypeOf(sql.NullString{}): {
+			TypeName: "string",
+			Nullable: true,
+		},
+		reflect.TypeOf(sql.NullInt32{}): {
+			TypeName: constInteger,
+			Format:   "int32",
+			Nullable: true,
+		},
+		reflect.TypeOf(sql.NullInt64{}): {
+			TypeName: constInteger,
+			Format:   "int64",
+			Nullable: true,
+		},
+		reflect.TypeOf(sql.NullFloat64{}): {
+			TypeName: constNumber,
+			Nullable: true,
+		},
+		reflect.TypeOf(sql.NullBool{}): {
+			TypeName: constBoolean,
+			Nullable: true,
+		},
+	}
+)
+
+// RegisterType registers the schema of type for all documents, e.g.
+// RegisterType(reflect.TypeOf(decimal.Decimal{}), TypeDictElement{TypeName: "string", Format: "decimal"})
+func RegisterType(t reflect.Type, e TypeDictElement) {
+	typeDictMu.Lock()
+	defer typeDictMu.Unlock()
+
+	typeDict[t] = e
+	invalidateDefinitions()
+}
+
+// lookupType returns the schema of type registered for document or for all
+// documents, the document registry is preferred
+func (s *Doc) lookupType(t reflect.Type) (TypeDictElement, bool) {
+	if e, ok := s.Types[t]; ok {
+		return e, true
+	}
+
+	typeDictMu.RLock()
+	defer typeDictMu.RUnlock()
+
+	e, ok := typeDict[t]
+	return e, ok
+}
+
+// schema returns the schema described by registered type
+func (e *TypeDictElement) schema() *Schema {
+	if e.Schema != nil {
+		schema := *e.Schema
+		return &schema
+	}
+
+	return &Schema{
+		TypeName: e.TypeName,
+		Format:   e.Format,
+		Pattern:  e.Pattern,
+		Example:  e.Example,
+		Nullable: e.Nullable,
+	}
+}
+
+// typeSchema returns the schema of registered type, of enumeration or of type
+// which describes itself. The value encoded by MarshalText is described as
+// string, the value encoded by MarshalJSON is described as free-form, the slice
+// of bytes is described as base64 string. Nil means that the schema is built by
+// reflection.
+func (s *Doc) typeSchema(t reflect.Type) *Schema {
+	if e, ok := s.lookupType(t); ok {
+		return e.schema()
+	}
+
+	if values, ok := s.lookupEnum(t); ok {
+		return enumSchema(t, values)
+	}
+
+	ptr := reflect.PtrTo(t)
+	if ptr.Implements(schemaDescriberType) {
+		if schema := reflect.New(t).Interface().(SchemaDescriber).SwaggerSchema(s); schema != nil {
+			return schema
+		}
+	}
+
+	switch {
+	case ptr.Implements(jsonMarshalerType):
+		return &Schema{FreeForm: true}
+	case ptr.Implements(textMarshalerType):
+		return &Schema{TypeName: "string"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// Slice of bytes is encoded as base64 string unless its items encode
+		// themselves
+		elem := reflect.PtrTo(t.Elem())
+		if !elem.Implements(jsonMarshalerType) && !elem.Implements(textMarshalerType) {
+			return &Schema{TypeName: "string", Format: "byte"}
+		}
+	}
+
+	return nil
+}
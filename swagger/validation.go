@@ -0,0 +1,215 @@
+package swagger
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tags with validation constraints
+const (
+	tagMinimum     = "swagminimum"
+	tagMaximum     = "swagmaximum"
+	tagMultipleOf  = "swagmultipleof"
+	tagMinLength   = "swagminlength"
+	tagMaxLength   = "swagmaxlength"
+	tagPattern     = "swagpattern"
+	tagMinItems    = "swagminitems"
+	tagMaxItems    = "swagmaxitems"
+	tagUniqueItems = "swaguniqueitems"
+	// Tag of go-playground/validator
+	tagValidate = "validate"
+)
+
+// Values of oneof rule, the quoted value could contain spaces
+var oneOfRegexp = regexp.MustCompile(`'[^']*'|\S+`)
+
+// parseConstraints fills validation constraints of property from the swag tags
+// and from the validate tag of go-playground/validator, the swag tags take
+// precedence. It returns true if the field is required by the validate tag.
+func (p *Property) parseConstraints(field reflect.StructField) (required bool) {
+	tp := field.Type
+	for tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+
+	if rules, ok := field.Tag.Lookup(tagValidate); ok {
+		required = p.parseValidateTag(rules, tp.Kind())
+	}
+
+	p.parseSwagConstraints(field.Tag)
+
+	return required
+}
+
+// parseValidateTag translates the rules of go-playground/validator to schema
+// constraints and formats
+func (p *Property) parseValidateTag(rules string, kind reflect.Kind) (required bool) {
+	for _, rule := range strings.Split(rules, ",") {
+		name, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			name, param = rule[:i], rule[i+1:]
+		}
+
+		switch name {
+		case "dive":
+			// Next rules are applied to items of array or map
+			return required
+		case "required":
+			required = true
+		case "min":
+			p.setMin(kind, param)
+		case "max":
+			p.setMax(kind, param)
+		case "len":
+			p.setMin(kind, param)
+			p.setMax(kind, param)
+		case "oneof":
+			p.Enum = nil
+			for _, value := range oneOfValues(param) {
+				p.Enum = append(p.Enum, parseKindValue(kind, value))
+			}
+		case "email":
+			p.setStringFormat(kind, "email")
+		case "uuid", "uuid3", "uuid4", "uuid5", "uuid_rfc4122", "uuid3_rfc4122", "uuid4_rfc4122", "uuid5_rfc4122":
+			p.setStringFormat(kind, "uuid")
+		case "url", "uri":
+			p.setStringFormat(kind, "uri")
+		}
+	}
+
+	return required
+}
+
+// parseSwagConstraints fills validation constraints from the swag tags
+func (p *Property) parseSwagConstraints(tag reflect.StructTag) {
+	for name, value := range map[string]**float64{
+		tagMinimum:    &p.Minimum,
+		tagMaximum:    &p.Maximum,
+		tagMultipleOf: &p.MultipleOf,
+	} {
+		if s, ok := tag.Lookup(name); ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				*value = &f
+			}
+		}
+	}
+
+	for name, value := range map[string]**int64{
+		tagMinLength: &p.MinLength,
+		tagMaxLength: &p.MaxLength,
+		tagMinItems:  &p.MinItems,
+		tagMaxItems:  &p.MaxItems,
+	} {
+		if s, ok := tag.Lookup(name); ok {
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				*value = &i
+			}
+		}
+	}
+
+	if pattern, ok := tag.Lookup(tagPattern); ok {
+		p.Pattern = pattern
+	}
+
+	if s, ok := tag.Lookup(tagUniqueItems); ok {
+		p.UniqueItems, _ = strconv.ParseBool(s)
+	}
+}
+
+// oneOfValues splits the parameter of oneof rule, the values with spaces are
+// quoted by single quotes like in validator, e.g. oneof='red green' blue
+func oneOfValues(param string) []string {
+	values := oneOfRegexp.FindAllString(param, -1)
+	for i, v := range values {
+		values[i] = strings.Replace(v, "'", "", -1)
+	}
+	return values
+}
+
+// setMin sets the minimum of number, the minimal length of string, the
+// minimal count of items of array or the minimal count of properties of map
+func (p *Property) setMin(kind reflect.Kind, param string) {
+	switch {
+	case kind == reflect.String:
+		p.MinLength = parseInt64(param)
+	case kind == reflect.Slice || kind == reflect.Array:
+		p.MinItems = parseInt64(param)
+	case kind == reflect.Map:
+		p.MinProperties = parseInt64(param)
+	case isNumberKind(kind):
+		p.Minimum = parseFloat64(param)
+	}
+}
+
+// setMax sets the maximum of number, the maximal length of string, the
+// maximal count of items of array or the maximal count of properties of map
+func (p *Property) setMax(kind reflect.Kind, param string) {
+	switch {
+	case kind == reflect.String:
+		p.MaxLength = parseInt64(param)
+	case kind == reflect.Slice || kind == reflect.Array:
+		p.MaxItems = parseInt64(param)
+	case kind == reflect.Map:
+		p.MaxProperties = parseInt64(param)
+	case isNumberKind(kind):
+		p.Maximum = parseFloat64(param)
+	}
+}
+
+func (p *Property) setStringFormat(kind reflect.Kind, format string) {
+	if kind == reflect.String {
+		p.Format = format
+	}
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func parseInt64(s string) *int64 {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &i
+}
+
+func parseFloat64(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+// parseKindValue converts the string value to the Go kind, the string is
+// returned if conversion failed
+func parseKindValue(kind reflect.Kind, s string) interface{} {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
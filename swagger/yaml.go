@@ -0,0 +1,68 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// jsonToYAML converts the JSON document to YAML keeping the order of keys
+func jsonToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(value)
+}
+
+// decodeOrdered decodes the JSON value, the objects are decoded to
+// yaml.MapSlice, so the order of keys is kept
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '[' {
+			items := make([]interface{}, 0)
+			for decoder.More() {
+				item, err := decodeOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			_, err = decoder.Token()
+			return items, err
+		}
+
+		object := make(yaml.MapSlice, 0)
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+
+	return token, nil
+}